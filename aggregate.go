@@ -0,0 +1,139 @@
+package enthstore
+
+import (
+	"context"
+	"errors"
+	"reflect"
+
+	"entgo.io/ent/dialect/sql"
+)
+
+// ErrInvalidQuery is the error returned by the aggregation helpers when
+// the query provided doesn't have the Modify method of the ent queries.
+var ErrInvalidQuery = errors.New("hstore: query must have the Modify method, enable the sql/modifier feature")
+
+// KeyStat is the number of rows that have the key.
+type KeyStat struct {
+	Key   string `json:"key"`
+	Count int    `json:"count"`
+}
+
+// ValueStat is the number of rows that have the value on a key.
+type ValueStat struct {
+	Value *string `json:"value"`
+	Count int     `json:"count"`
+}
+
+// SizeStat is the number of rows that have the number of keys.
+type SizeStat struct {
+	Size  int `json:"size"`
+	Count int `json:"count"`
+}
+
+// KeyFrequency returns the keys of the column with the number of rows that
+// have the key, ordered by the most frequent keys. The query must be an ent
+// query generated with the sql/modifier feature, its predicates are kept
+// and it must not have an order:
+//
+//	stats, err := enthstore.KeyFrequency(ctx, client.User.Query(), user.FieldAttributes)
+func KeyFrequency(ctx context.Context, query interface{}, column string) ([]KeyStat, error) {
+	var stats []KeyStat
+	err := scanAggregate(ctx, query, &stats, func(s *sql.Selector) {
+		s.Select().
+			AppendSelectExprAs(sql.ExprFunc(func(b *sql.Builder) {
+				b.WriteString("skeys(").Ident(s.C(column)).WriteString(")")
+			}), "key").
+			AppendSelectExprAs(sql.Expr("count(*)"), "count").
+			GroupBy("key").
+			OrderBy(sql.Desc("count"), "key")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// ValueFrequency returns the values of the key with the number of rows that have
+// the value, ordered by the most frequent values. The rows without the key are
+// ignored and the NULL values are returned as a nil Value.
+func ValueFrequency(ctx context.Context, query interface{}, column string, key string) ([]ValueStat, error) {
+	var stats []ValueStat
+	err := scanAggregate(ctx, query, &stats, func(s *sql.Selector) {
+		s.Select().
+			AppendSelectExprAs(sql.ExprFunc(func(b *sql.Builder) {
+				Value(s.C(column), key).writeTo(b)
+			}), "value").
+			AppendSelectExprAs(sql.Expr("count(*)"), "count").
+			Where(HasKey(s.C(column), key)).
+			GroupBy("value").
+			OrderBy(sql.Desc("count"), "value")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// SizeFrequency returns the number of keys of the rows with the number
+// of rows that have that many keys, ordered by the number of keys.
+// The NULL columns are counted as rows without keys, unlike SelectSize,
+// which selects the number of keys of every row and NULL for them.
+func SizeFrequency(ctx context.Context, query interface{}, column string) ([]SizeStat, error) {
+	var stats []SizeStat
+	err := scanAggregate(ctx, query, &stats, func(s *sql.Selector) {
+		size := column + "_size"
+		s.Select().
+			AppendSelectExprAs(sql.ExprFunc(func(b *sql.Builder) {
+				b.WriteString("COALESCE(")
+				writeKeyCount(b, s.C(column))
+				b.WriteString(", 0)")
+			}), size).
+			AppendSelectExprAs(sql.Expr("count(*)"), "count").
+			GroupBy(size).
+			OrderBy(size)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// selectScanner is implemented by the selects returned
+// by the Modify method of the ent queries.
+type selectScanner interface {
+	Scan(ctx context.Context, v interface{}) error
+}
+
+// scanAggregate calls the Modify method of a clone of the ent query with
+// the modifier and scans the result into v, the methods are called with
+// reflection since every ent query returns a different type.
+func scanAggregate(ctx context.Context, query interface{}, v interface{}, modifier func(*sql.Selector)) error {
+	rv := reflect.ValueOf(query)
+	if !rv.IsValid() {
+		return ErrInvalidQuery
+	}
+
+	clone := rv.MethodByName("Clone")
+	if clone.IsValid() && clone.Type().NumIn() == 0 && clone.Type().NumOut() == 1 && clone.Type().Out(0) == rv.Type() {
+		rv = clone.Call(nil)[0]
+	}
+
+	modify := rv.MethodByName("Modify")
+	if !modify.IsValid() ||
+		!modify.Type().IsVariadic() ||
+		modify.Type().NumIn() != 1 ||
+		modify.Type().NumOut() != 1 ||
+		!reflect.TypeOf(modifier).AssignableTo(modify.Type().In(0).Elem()) {
+		return ErrInvalidQuery
+	}
+
+	s, ok := modify.Call([]reflect.Value{reflect.ValueOf(modifier)})[0].Interface().(selectScanner)
+	if !ok {
+		return ErrInvalidQuery
+	}
+
+	return s.Scan(ctx, v)
+}
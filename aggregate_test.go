@@ -0,0 +1,102 @@
+package enthstore
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeQuery mimics the Modify method of the ent queries.
+type fakeQuery struct {
+	query *string
+	args  *[]interface{}
+}
+
+func (q *fakeQuery) Modify(modifiers ...func(*sql.Selector)) *fakeSelect {
+	return &fakeSelect{query: q, modifiers: modifiers}
+}
+
+type fakeSelect struct {
+	query     *fakeQuery
+	modifiers []func(*sql.Selector)
+}
+
+func (s *fakeSelect) Scan(_ context.Context, _ interface{}) error {
+	users := sql.Table("users")
+	selector := sql.Dialect(dialect.Postgres).Select(users.C("id")).From(users).Where(sql.EQ(users.C("id"), 1))
+	for _, m := range s.modifiers {
+		m(selector)
+	}
+
+	*s.query.query, *s.query.args = selector.Query()
+	return nil
+}
+
+func TestAggregations(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input     func(ctx context.Context, query interface{}) error
+		wantQuery string
+		wantArgs  []interface{}
+	}{
+		{
+			input: func(ctx context.Context, query interface{}) error {
+				_, err := KeyFrequency(ctx, query, "attributes")
+				return err
+			},
+			wantQuery: `SELECT (skeys("users"."attributes")) AS "key", (count(*)) AS "count" FROM "users" ` +
+				`WHERE "users"."id" = $1 GROUP BY "key" ORDER BY "count" DESC, "key"`,
+			wantArgs: []interface{}{1},
+		},
+		{
+			input: func(ctx context.Context, query interface{}) error {
+				_, err := ValueFrequency(ctx, query, "attributes", "it's")
+				return err
+			},
+			wantQuery: `SELECT ("users"."attributes" -> 'it''s') AS "value", (count(*)) AS "count" FROM "users" ` +
+				`WHERE "users"."id" = $1 AND "users"."attributes" ? 'it''s' GROUP BY "value" ORDER BY "count" DESC, "value"`,
+			wantArgs: []interface{}{1},
+		},
+		{
+			input: func(ctx context.Context, query interface{}) error {
+				_, err := SizeFrequency(ctx, query, "attributes")
+				return err
+			},
+			wantQuery: `SELECT (COALESCE(cardinality(akeys("users"."attributes")), 0)) AS "attributes_size", (count(*)) AS "count" ` +
+				`FROM "users" WHERE "users"."id" = $1 GROUP BY "attributes_size" ORDER BY "attributes_size"`,
+			wantArgs: []interface{}{1},
+		},
+	}
+	for i, tt := range tests {
+		tt := tt
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+			var (
+				query string
+				args  []interface{}
+			)
+			err := tt.input(context.Background(), &fakeQuery{query: &query, args: &args})
+			require.NoError(t, err)
+			require.Equal(t, tt.wantQuery, query)
+			require.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestAggregations_invalidQuery(t *testing.T) {
+	t.Parallel()
+
+	for i, query := range []interface{}{nil, 1, fakeSelect{}, &fakeSelect{}} {
+		query := query
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+			_, err := KeyFrequency(context.Background(), query, "attributes")
+			require.ErrorIs(t, err, ErrInvalidQuery)
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package enthstore
+
+import (
+	"strings"
+)
+
+// TextArray represents a one-dimensional text array of Postgres,
+// like the keys and values selected by SelectKeys and SelectValues,
+// the NULL elements are nil.
+type TextArray []*string
+
+// Strings returns the elements of the array,
+// the NULL elements are returned as empty strings.
+func (a TextArray) Strings() []string {
+	values := make([]string, len(a))
+	for i, v := range a {
+		if v != nil {
+			values[i] = *v
+		}
+	}
+
+	return values
+}
+
+// Scan implements the interface Scanner.
+func (a *TextArray) Scan(value interface{}) error {
+	if value == nil {
+		*a = nil
+		return nil
+	}
+
+	input, err := scanInput(value)
+	if err != nil {
+		return err
+	}
+
+	p := parser{input: input, strict: true}
+	values, err := p.parseArray()
+	if err != nil {
+		return err
+	}
+
+	*a = values
+	return nil
+}
+
+// parseArray parses the text representation of a text array.
+func (p *parser) parseArray() (TextArray, error) {
+	p.skipSpaces()
+	if err := p.expect('{', `"{"`); err != nil {
+		return nil, err
+	}
+
+	values := TextArray{}
+
+	p.skipSpaces()
+	if !p.eof() && p.input[p.pos] == '}' {
+		p.pos++
+		return values, p.arrayEnd()
+	}
+
+	for {
+		p.skipSpaces()
+
+		val, err := p.arrayElement()
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, val)
+
+		p.skipSpaces()
+		if !p.eof() && p.input[p.pos] == '}' {
+			p.pos++
+			return values, p.arrayEnd()
+		}
+
+		if err := p.expect(',', `"," or "}"`); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// arrayElement reads a quoted or an unquoted element, the
+// unquoted elements end at a delimiter and NULL is nil.
+func (p *parser) arrayElement() (*string, error) {
+	if p.eof() {
+		return nil, p.errorAt("element")
+	}
+
+	if p.input[p.pos] == '"' {
+		p.pos++
+		s, err := p.quoted()
+		if err != nil {
+			return nil, err
+		}
+
+		return &s, nil
+	}
+
+	start := p.pos
+	escaped := false
+
+	for !p.eof() {
+		c := p.input[p.pos]
+		if c == ',' || c == '}' || c == '"' || c == '{' {
+			break
+		}
+
+		if c == '\\' {
+			escaped = true
+			p.pos++
+			if p.eof() {
+				return nil, p.errorAt("escaped character")
+			}
+		}
+
+		p.pos++
+	}
+
+	s := strings.TrimRight(p.input[start:p.pos], " \t\n\r\v\f")
+	if s == "" {
+		return nil, p.errorAt("element")
+	}
+
+	if !escaped && strings.EqualFold(s, "NULL") {
+		return nil, nil
+	}
+
+	if escaped {
+		s = p.unescape(s)
+	}
+
+	return &s, nil
+}
+
+func (p *parser) arrayEnd() error {
+	p.skipSpaces()
+	if !p.eof() {
+		return p.errorAt("end of input")
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package enthstore
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTextArray_Scan(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input   interface{}
+		want    TextArray
+		wantErr string
+	}{
+		{input: nil, want: nil},
+		{input: "{}", want: TextArray{}},
+		{input: []byte("{a,b}"), want: TextArray{ptrString("a"), ptrString("b")}},
+		{input: `{"a b","c\"d","e\\f",NULL,"NULL",a=>b}`, want: TextArray{
+			ptrString("a b"), ptrString(`c"d`), ptrString(`e\f`), nil, ptrString("NULL"), ptrString("a=>b"),
+		}},
+		{input: ` { a , b } `, want: TextArray{ptrString("a"), ptrString("b")}},
+		{input: `{a\,b}`, want: TextArray{ptrString("a,b")}},
+		{input: "a,b", wantErr: `hstore: unexpected 'a' at offset 0, expected "{"`},
+		{input: "{a,}", wantErr: `hstore: unexpected '}' at offset 3, expected element`},
+		{input: "{a", wantErr: `hstore: unexpected end of input at offset 2, expected "," or "}"`},
+		{input: `{"a}`, wantErr: `hstore: unexpected end of input at offset 4, expected closing '"'`},
+		{input: "{a}b", wantErr: `hstore: unexpected 'b' at offset 3, expected end of input`},
+		{input: 1, wantErr: "invalid input type: int"},
+	}
+	for i, tt := range tests {
+		tt := tt
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+			var got TextArray
+			err := got.Scan(tt.input)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTextArray_Strings(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, []string{"a", ""}, TextArray{ptrString("a"), nil}.Strings())
+	require.Equal(t, []string{}, TextArray{}.Strings())
+}
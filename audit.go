@@ -0,0 +1,320 @@
+package enthstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"entgo.io/ent"
+)
+
+// ErrAuditBulkUpdate is the error returned by the AuditHook when the rows
+// of a bulk update can't be loaded, the mutation must provide the IDs and
+// Client methods generated by ent to query the old values of the rows.
+var ErrAuditBulkUpdate = errors.New("hstore: audit of bulk updates is not supported")
+
+// AuditEntry is the change of an Hstore field recorded by the AuditHook.
+type AuditEntry struct {
+	// Type is the schema type of the mutation, like "User".
+	Type string
+	// Op is the operation of the mutation.
+	Op ent.Op
+	// ID is the id of the entity.
+	ID interface{}
+	// Field is the name of the changed field.
+	Field string
+	// Actor is the actor returned by the actor function.
+	Actor string
+	// Patch holds the keys added, removed and changed.
+	Patch Patch
+}
+
+// AuditFunc receives the entries of a mutation, it is called after the
+// mutation is executed, returning an error fails the mutation, which
+// rollbacks the changes when the mutation runs on a transaction.
+type AuditFunc func(ctx context.Context, entries []AuditEntry) error
+
+// AuditOption configures the AuditHook.
+type AuditOption func(*auditOptions)
+
+type auditOptions struct {
+	actor func(ctx context.Context) string
+}
+
+// AuditActor defines the function that returns the actor of the
+// changes, the default is ActorFromContext.
+func AuditActor(fn func(ctx context.Context) string) AuditOption {
+	return func(o *auditOptions) {
+		o.actor = fn
+	}
+}
+
+type actorContextKey struct{}
+
+// WithActor returns a context holding the actor used by the AuditHook.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorContextKey{}, actor)
+}
+
+// ActorFromContext returns the actor stored on the context by WithActor.
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorContextKey{}).(string)
+	return actor
+}
+
+// AuditHook returns an ent.Hook that records the changes of the Hstore and
+// Typed fields, for every field changed an AuditEntry with the Patch between
+// the old and new values is sent to fn. It handles the create and update
+// operations, the deletes are ignored. A bulk update loads the rows matching
+// its predicates before the mutation, one query for the ids and one for every
+// row, and records the entries of every row, run it in a transaction with a
+// serializable isolation level to keep the rows loaded and updated the same.
+// The changes made by the update modifiers, like SetKey, are not part of the
+// mutation and are not recorded.
+//
+//	client.User.Use(enthstore.AuditHook(func(ctx context.Context, entries []enthstore.AuditEntry) error {
+//		// save the entries.
+//	}))
+func AuditHook(fn AuditFunc, opts ...AuditOption) ent.Hook {
+	o := auditOptions{actor: ActorFromContext}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			entries, err := auditEntries(ctx, m)
+			if err != nil {
+				return nil, err
+			}
+
+			v, err := next.Mutate(ctx, m)
+			if err != nil || len(entries) == 0 {
+				return v, err
+			}
+
+			id := mutationID(m)
+			actor := o.actor(ctx)
+			for i := range entries {
+				if entries[i].ID == nil {
+					entries[i].ID = id
+				}
+
+				entries[i].Actor = actor
+			}
+
+			if err := fn(ctx, entries); err != nil {
+				return nil, err
+			}
+
+			return v, nil
+		})
+	}
+}
+
+// auditEntries returns the entries of the Hstore fields changed by the mutation,
+// the entries of a bulk update are returned for every row it updates.
+func auditEntries(ctx context.Context, m ent.Mutation) ([]AuditEntry, error) {
+	switch op := m.Op(); {
+	case op.Is(ent.OpCreate):
+		return diffEntries(ctx, m, nil, nil)
+	case op.Is(ent.OpUpdateOne):
+		return diffEntries(ctx, m, m, nil)
+	case op.Is(ent.OpUpdate):
+		if !changesHstore(m) {
+			return nil, nil
+		}
+
+		ids, rows, err := rowMutations(ctx, m)
+		if err != nil {
+			return nil, err
+		}
+
+		var entries []AuditEntry
+		for i, row := range rows {
+			rowEntries, err := diffEntries(ctx, m, row, ids[i])
+			if err != nil {
+				return nil, err
+			}
+
+			entries = append(entries, rowEntries...)
+		}
+
+		return entries, nil
+	}
+
+	return nil, nil
+}
+
+// changesHstore reports whether the mutation may change an Hstore field,
+// the type of the cleared fields is only known from their old values.
+func changesHstore(m ent.Mutation) bool {
+	if len(m.ClearedFields()) > 0 {
+		return true
+	}
+
+	for _, field := range m.Fields() {
+		if v, _ := m.Field(field); isHstoreValue(v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// diffEntries returns the entries of the Hstore fields changed by the mutation,
+// the old values are read from old, which is nil when there are no old values.
+func diffEntries(ctx context.Context, m ent.Mutation, old ent.Mutation, id interface{}) ([]AuditEntry, error) {
+	var entries []AuditEntry
+	for _, field := range append(m.Fields(), m.ClearedFields()...) {
+		newValue, _ := m.Field(field)
+		if m.FieldCleared(field) {
+			newValue = nil
+		}
+
+		if newValue != nil && !isHstoreValue(newValue) {
+			continue
+		}
+
+		var oldValue ent.Value
+		if old != nil {
+			v, err := old.OldField(ctx, field)
+			if err != nil {
+				return nil, err
+			}
+
+			oldValue = v
+		}
+
+		if !isHstoreValue(newValue) && !isHstoreValue(oldValue) {
+			continue
+		}
+
+		oldHstore, err := toHstore(oldValue)
+		if err != nil {
+			return nil, err
+		}
+
+		newHstore, err := toHstore(newValue)
+		if err != nil {
+			return nil, err
+		}
+
+		if patch := Diff(oldHstore, newHstore); !patch.IsEmpty() {
+			entries = append(entries, AuditEntry{
+				Type:  m.Type(),
+				Op:    m.Op(),
+				ID:    id,
+				Field: field,
+				Patch: patch,
+			})
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Field < entries[j].Field
+	})
+
+	return entries, nil
+}
+
+// rowMutations returns the ids of the rows matching the predicates of a bulk
+// mutation and an update one mutation of every row, which is only used to
+// query the old values. The IDs and Client methods of the mutation, and the
+// UpdateOneID method of the entity client, are generated with the type of the
+// id field, they are called by reflection like the ID method in mutationID.
+func rowMutations(ctx context.Context, m ent.Mutation) ([]interface{}, []ent.Mutation, error) {
+	v := reflect.ValueOf(m)
+	idsMethod := v.MethodByName("IDs")
+	clientMethod := v.MethodByName("Client")
+	if !idsMethod.IsValid() || !clientMethod.IsValid() {
+		return nil, nil, fmt.Errorf("%w: mutation of %s", ErrAuditBulkUpdate, m.Type())
+	}
+
+	out := idsMethod.Call([]reflect.Value{reflect.ValueOf(ctx)})
+	if err, _ := out[1].Interface().(error); err != nil {
+		return nil, nil, err
+	}
+
+	client := reflect.Indirect(clientMethod.Call(nil)[0])
+	entityClient := client.FieldByName(m.Type())
+	if !entityClient.IsValid() {
+		return nil, nil, fmt.Errorf("%w: client of %s", ErrAuditBulkUpdate, m.Type())
+	}
+
+	updateOne := entityClient.MethodByName("UpdateOneID")
+	if !updateOne.IsValid() {
+		return nil, nil, fmt.Errorf("%w: client of %s", ErrAuditBulkUpdate, m.Type())
+	}
+
+	ids := make([]interface{}, 0, out[0].Len())
+	rows := make([]ent.Mutation, 0, out[0].Len())
+	for i := 0; i < out[0].Len(); i++ {
+		id := out[0].Index(i)
+		builder := updateOne.Call([]reflect.Value{id})[0]
+		mutation := builder.MethodByName("Mutation")
+		if !mutation.IsValid() {
+			return nil, nil, fmt.Errorf("%w: update of %s", ErrAuditBulkUpdate, m.Type())
+		}
+
+		row, ok := mutation.Call(nil)[0].Interface().(ent.Mutation)
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: update of %s", ErrAuditBulkUpdate, m.Type())
+		}
+
+		ids = append(ids, id.Interface())
+		rows = append(rows, row)
+	}
+
+	return ids, rows, nil
+}
+
+// hstorer is implemented by Typed.
+type hstorer interface {
+	Hstore() (Hstore, error)
+}
+
+func isHstoreValue(v ent.Value) bool {
+	switch v.(type) {
+	case Hstore, *Hstore, hstorer:
+		return true
+	}
+
+	return false
+}
+
+// toHstore returns the Hstore of a field value, nil values are nil.
+func toHstore(v ent.Value) (Hstore, error) {
+	switch v := v.(type) {
+	case Hstore:
+		return v, nil
+	case *Hstore:
+		if v == nil {
+			return nil, nil
+		}
+
+		return *v, nil
+	case hstorer:
+		return v.Hstore()
+	}
+
+	return nil, nil
+}
+
+// mutationID returns the id of the mutation, the ID method of
+// the mutations is generated with the type of the id field.
+func mutationID(m ent.Mutation) interface{} {
+	method := reflect.ValueOf(m).MethodByName("ID")
+	if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 2 {
+		return nil
+	}
+
+	out := method.Call(nil)
+	if exists, _ := out[1].Interface().(bool); !exists {
+		return nil
+	}
+
+	return out[0].Interface()
+}
@@ -0,0 +1,286 @@
+package enthstore
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"entgo.io/ent"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeMutation implements the methods of ent.Mutation used by the AuditHook.
+type fakeMutation struct {
+	ent.Mutation
+	op      ent.Op
+	id      *int
+	fields  map[string]ent.Value
+	old     map[string]ent.Value
+	cleared []string
+}
+
+func (m *fakeMutation) Op() ent.Op   { return m.op }
+func (m *fakeMutation) Type() string { return "User" }
+
+func (m *fakeMutation) Fields() []string {
+	fields := make([]string, 0, len(m.fields))
+	for f := range m.fields {
+		fields = append(fields, f)
+	}
+
+	return fields
+}
+
+func (m *fakeMutation) Field(name string) (ent.Value, bool) {
+	v, ok := m.fields[name]
+	return v, ok
+}
+
+func (m *fakeMutation) OldField(_ context.Context, name string) (ent.Value, error) {
+	return m.old[name], nil
+}
+
+func (m *fakeMutation) ClearedFields() []string { return m.cleared }
+
+func (m *fakeMutation) FieldCleared(name string) bool {
+	for _, f := range m.cleared {
+		if f == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *fakeMutation) ID() (int, bool) {
+	if m.id == nil {
+		return 0, false
+	}
+
+	return *m.id, true
+}
+
+func TestAuditHook(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		mutation *fakeMutation
+		want     []AuditEntry
+		wantErr  error
+	}{
+		{
+			mutation: &fakeMutation{op: ent.OpCreate, fields: map[string]ent.Value{
+				"name":       "name",
+				"attributes": Hstore{"a": ptrString("b")},
+				"settings":   Hstore{},
+			}},
+			want: []AuditEntry{{
+				Type:  "User",
+				Op:    ent.OpCreate,
+				ID:    1,
+				Field: "attributes",
+				Actor: "actor",
+				Patch: Patch{Added: Hstore{"a": ptrString("b")}},
+			}},
+		},
+		{
+			mutation: &fakeMutation{
+				op: ent.OpUpdateOne,
+				fields: map[string]ent.Value{
+					"attributes": Hstore{"a": nil, "c": ptrString("d")},
+					"settings":   NewTyped(typedStruct{Name: "b"}),
+				},
+				old: map[string]ent.Value{
+					"attributes": Hstore{"a": ptrString("b")},
+					"settings":   NewTyped(typedStruct{Name: "a"}),
+					"other":      Hstore{"a": ptrString("b")},
+				},
+				cleared: []string{"other"},
+			},
+			want: []AuditEntry{
+				{
+					Type:  "User",
+					Op:    ent.OpUpdateOne,
+					ID:    1,
+					Field: "attributes",
+					Actor: "actor",
+					Patch: Patch{
+						Added:   Hstore{"c": ptrString("d")},
+						Changed: map[string]Change{"a": {Old: ptrString("b"), New: nil}},
+					},
+				},
+				{
+					Type:  "User",
+					Op:    ent.OpUpdateOne,
+					ID:    1,
+					Field: "other",
+					Actor: "actor",
+					Patch: Patch{Removed: Hstore{"a": ptrString("b")}},
+				},
+				{
+					Type:  "User",
+					Op:    ent.OpUpdateOne,
+					ID:    1,
+					Field: "settings",
+					Actor: "actor",
+					Patch: Patch{Changed: map[string]Change{"name": {Old: ptrString("a"), New: ptrString("b")}}},
+				},
+			},
+		},
+		{
+			mutation: &fakeMutation{
+				op:     ent.OpUpdateOne,
+				fields: map[string]ent.Value{"attributes": Hstore{"a": ptrString("b")}},
+				old:    map[string]ent.Value{"attributes": Hstore{"a": ptrString("b")}},
+			},
+		},
+		{
+			mutation: &fakeMutation{op: ent.OpUpdate, fields: map[string]ent.Value{"name": "name"}},
+		},
+		{
+			mutation: &fakeMutation{op: ent.OpUpdate, fields: map[string]ent.Value{"attributes": Hstore{}}},
+			wantErr:  ErrAuditBulkUpdate,
+		},
+		{
+			mutation: &fakeMutation{op: ent.OpUpdate, cleared: []string{"attributes"}},
+			wantErr:  ErrAuditBulkUpdate,
+		},
+		{
+			mutation: &fakeMutation{op: ent.OpDeleteOne},
+		},
+	}
+	for i, tt := range tests {
+		tt := tt
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+			var got []AuditEntry
+			hook := AuditHook(func(ctx context.Context, entries []AuditEntry) error {
+				got = entries
+				return nil
+			})
+
+			mutator := hook(ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+				id := 1
+				tt.mutation.id = &id
+				return "value", nil
+			}))
+
+			v, err := mutator.Mutate(WithActor(context.Background(), "actor"), tt.mutation)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "value", v)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// fakeBulkMutation implements the methods generated by ent
+// used by the AuditHook to load the rows of a bulk update.
+type fakeBulkMutation struct {
+	*fakeMutation
+	ids  []int
+	rows map[int]*fakeMutation
+}
+
+func (m *fakeBulkMutation) IDs(context.Context) ([]int, error) { return m.ids, nil }
+func (m *fakeBulkMutation) Client() *fakeClient {
+	return &fakeClient{User: &fakeUserClient{rows: m.rows}}
+}
+
+type fakeClient struct {
+	User *fakeUserClient
+}
+
+type fakeUserClient struct {
+	rows map[int]*fakeMutation
+}
+
+func (c *fakeUserClient) UpdateOneID(id int) *fakeUpdateOne {
+	return &fakeUpdateOne{mutation: c.rows[id]}
+}
+
+type fakeUpdateOne struct {
+	mutation *fakeMutation
+}
+
+func (u *fakeUpdateOne) Mutation() *fakeMutation { return u.mutation }
+
+func TestAuditHook_bulkUpdate(t *testing.T) {
+	t.Parallel()
+
+	m := &fakeBulkMutation{
+		fakeMutation: &fakeMutation{
+			op:      ent.OpUpdate,
+			fields:  map[string]ent.Value{"attributes": Hstore{"a": ptrString("c")}},
+			cleared: []string{"name"},
+		},
+		ids: []int{1, 2, 3},
+		rows: map[int]*fakeMutation{
+			1: {old: map[string]ent.Value{"attributes": Hstore{"a": ptrString("b")}, "name": "a"}},
+			2: {old: map[string]ent.Value{"attributes": Hstore{"a": ptrString("c")}, "name": "b"}},
+			3: {old: map[string]ent.Value{"attributes": Hstore{"x": nil}}},
+		},
+	}
+
+	var got []AuditEntry
+	hook := AuditHook(func(ctx context.Context, entries []AuditEntry) error {
+		got = entries
+		return nil
+	})
+
+	mutator := hook(ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+		return 2, nil
+	}))
+
+	v, err := mutator.Mutate(WithActor(context.Background(), "actor"), m)
+	require.NoError(t, err)
+	require.Equal(t, 2, v)
+	require.Equal(t, []AuditEntry{
+		{
+			Type:  "User",
+			Op:    ent.OpUpdate,
+			ID:    1,
+			Field: "attributes",
+			Actor: "actor",
+			Patch: Patch{Changed: map[string]Change{"a": {Old: ptrString("b"), New: ptrString("c")}}},
+		},
+		{
+			Type:  "User",
+			Op:    ent.OpUpdate,
+			ID:    3,
+			Field: "attributes",
+			Actor: "actor",
+			Patch: Patch{Added: Hstore{"a": ptrString("c")}, Removed: Hstore{"x": nil}},
+		},
+	}, got)
+}
+
+func TestAuditHook_errors(t *testing.T) {
+	t.Parallel()
+
+	errAudit := errors.New("audit")
+	hook := AuditHook(func(ctx context.Context, entries []AuditEntry) error {
+		require.Equal(t, "custom", entries[0].Actor)
+		require.Nil(t, entries[0].ID)
+		return errAudit
+	}, AuditActor(func(ctx context.Context) string {
+		return "custom"
+	}))
+
+	mutator := hook(ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+		return "value", nil
+	}))
+
+	_, err := mutator.Mutate(context.Background(), &fakeMutation{
+		op:     ent.OpCreate,
+		fields: map[string]ent.Value{"attributes": Hstore{"a": nil}},
+	})
+	require.ErrorIs(t, err, errAudit)
+
+	require.Empty(t, ActorFromContext(context.Background()))
+}
@@ -0,0 +1,246 @@
+package enthstore
+
+import (
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+)
+
+// ValueType is a Postgres type the values of an hstore can be cast to.
+type ValueType struct {
+	name string
+	// pattern matches the text values that can be cast,
+	// it's used by guarded casts.
+	pattern string
+}
+
+// String returns the Postgres name of the type.
+func (t ValueType) String() string {
+	return t.name
+}
+
+var (
+	// Numeric casts the values to numeric.
+	Numeric = ValueType{name: "numeric", pattern: `^\s*[-+]?(\d+(\.\d*)?|\.\d+)(e[-+]?\d+)?\s*$`}
+	// Integer casts the values to bigint.
+	Integer = ValueType{name: "bigint", pattern: `^\s*[-+]?\d{1,18}\s*$`}
+	// Date casts the values to date, the guard accepts the ISO 8601 format.
+	Date = ValueType{name: "date", pattern: `^\s*\d{4}-\d{2}-\d{2}\s*$`}
+	// Timestamp casts the values to timestamptz, the guard accepts the ISO 8601 format.
+	Timestamp = ValueType{
+		name:    "timestamptz",
+		pattern: `^\s*\d{4}-\d{2}-\d{2}([ t]\d{2}:\d{2}(:\d{2}(\.\d+)?)?)?\s*(z|[-+]\d{2}(:?\d{2})?)?\s*$`,
+	}
+	// Boolean casts the values to boolean.
+	Boolean = ValueType{name: "boolean", pattern: `^\s*(t|true|f|false|y|yes|n|no|on|off|1|0)\s*$`}
+)
+
+// KeyValue is the value of a key of an hstore column.
+type KeyValue struct {
+	column string
+	key    string
+}
+
+// Value returns the value of the key of the given column, it must
+// be cast to be compared with typed values, only on Postgres:
+//
+//	enthstore.Value(user.FieldAttributes, "age").As(enthstore.Numeric).GT(18)
+func Value(column string, key string) KeyValue {
+	return KeyValue{column: column, key: key}
+}
+
+// As casts the value to the provided type.
+func (v KeyValue) As(t ValueType) CastValue {
+	return CastValue{KeyValue: v, typ: t}
+}
+
+// CastValue is the value of a key of an hstore column cast to a type.
+//
+// A value that can't be cast fails the whole query,
+// use Guard to ignore those values.
+type CastValue struct {
+	KeyValue
+	typ   ValueType
+	guard bool
+}
+
+// Guard returns a CastValue that only casts the values matching the
+// format of the type, the other values are handled as NULL. The guard
+// checks the format only, values like "2022-13-01" still fail the cast.
+func (c CastValue) Guard() CastValue {
+	c.guard = true
+	return c
+}
+
+// EQ checks if the value is equals to the provided value.
+func (c CastValue) EQ(val interface{}) *sql.Predicate {
+	return c.compare(sql.OpEQ, val).Predicate
+}
+
+// NEQ checks if the value is not equals to the provided value.
+func (c CastValue) NEQ(val interface{}) *sql.Predicate {
+	return c.compare(sql.OpNEQ, val).Predicate
+}
+
+// GT checks if the value is greater than the provided value.
+func (c CastValue) GT(val interface{}) *sql.Predicate {
+	return c.compare(sql.OpGT, val).Predicate
+}
+
+// GTE checks if the value is greater or equals to the provided value.
+func (c CastValue) GTE(val interface{}) *sql.Predicate {
+	return c.compare(sql.OpGTE, val).Predicate
+}
+
+// LT checks if the value is smaller than the provided value.
+func (c CastValue) LT(val interface{}) *sql.Predicate {
+	return c.compare(sql.OpLT, val).Predicate
+}
+
+// LTE checks if the value is smaller or equals to the provided value.
+func (c CastValue) LTE(val interface{}) *sql.Predicate {
+	return c.compare(sql.OpLTE, val).Predicate
+}
+
+// Between checks if the value is between the provided values, inclusive.
+func (c CastValue) Between(from, to interface{}) *sql.Predicate {
+	return c.between(from, to).Predicate
+}
+
+func (c CastValue) between(from, to interface{}) *Predicate {
+	return newPredicate(sql.P(func(b *sql.Builder) {
+		c.writeTo(b)
+		b.WriteString(" BETWEEN ").Arg(from).WriteString(" AND ").Arg(to)
+	}), c.match(func(val string) (bool, error) {
+		lower, err := c.typ.compare(val, from)
+		if err != nil {
+			return false, err
+		}
+
+		upper, err := c.typ.compare(val, to)
+		if err != nil {
+			return false, err
+		}
+
+		return lower >= 0 && upper <= 0, nil
+	}))
+}
+
+// MatchableKeyValue is the value of a key of an hstore column
+// used by the predicates evaluable by Match.
+type MatchableKeyValue struct {
+	v KeyValue
+}
+
+// Value returns the value of the key of the given column, like Value.
+func (MatchablePredicates) Value(column string, key string) MatchableKeyValue {
+	return MatchableKeyValue{v: Value(column, key)}
+}
+
+// As casts the value to the provided type.
+func (v MatchableKeyValue) As(t ValueType) MatchableCastValue {
+	return MatchableCastValue{c: v.v.As(t)}
+}
+
+// MatchableCastValue is a CastValue whose predicates are evaluable by Match.
+type MatchableCastValue struct {
+	c CastValue
+}
+
+// Guard returns a MatchableCastValue that only casts the values matching
+// the format of the type, like CastValue.Guard.
+func (m MatchableCastValue) Guard() MatchableCastValue {
+	m.c = m.c.Guard()
+	return m
+}
+
+// EQ checks if the value is equals to the provided value.
+func (m MatchableCastValue) EQ(val interface{}) *Predicate {
+	return m.c.compare(sql.OpEQ, val)
+}
+
+// NEQ checks if the value is not equals to the provided value.
+func (m MatchableCastValue) NEQ(val interface{}) *Predicate {
+	return m.c.compare(sql.OpNEQ, val)
+}
+
+// GT checks if the value is greater than the provided value.
+func (m MatchableCastValue) GT(val interface{}) *Predicate {
+	return m.c.compare(sql.OpGT, val)
+}
+
+// GTE checks if the value is greater or equals to the provided value.
+func (m MatchableCastValue) GTE(val interface{}) *Predicate {
+	return m.c.compare(sql.OpGTE, val)
+}
+
+// LT checks if the value is smaller than the provided value.
+func (m MatchableCastValue) LT(val interface{}) *Predicate {
+	return m.c.compare(sql.OpLT, val)
+}
+
+// LTE checks if the value is smaller or equals to the provided value.
+func (m MatchableCastValue) LTE(val interface{}) *Predicate {
+	return m.c.compare(sql.OpLTE, val)
+}
+
+// Between checks if the value is between the provided values, inclusive.
+func (m MatchableCastValue) Between(from, to interface{}) *Predicate {
+	return m.c.between(from, to)
+}
+
+func (c CastValue) compare(op sql.Op, arg interface{}) *Predicate {
+	return newPredicate(sql.P(func(b *sql.Builder) {
+		c.writeTo(b)
+		b.WriteOp(op).Arg(arg)
+	}), c.match(func(val string) (bool, error) {
+		n, err := c.typ.compare(val, arg)
+		return compareOp(op, n), err
+	}))
+}
+
+// match returns a matcher that handles the values
+// not matching the guard as unknown.
+func (c CastValue) match(fn func(val string) (bool, error)) matcher {
+	return matchValue(c.key, func(val string) (truth, error) {
+		if c.guard && !c.typ.matches(val) {
+			return unknown, nil
+		}
+
+		ok, err := fn(val)
+		if err != nil {
+			return unknown, err
+		}
+
+		return truthOf(ok), nil
+	})
+}
+
+// writeTo writes the cast expression, a guarded cast is written as a CASE
+// expression, since Postgres doesn't guarantee the evaluation order of
+// the conditions of the WHERE clause. The casts are only supported by
+// Postgres, the other dialects add an error to the builder.
+func (c CastValue) writeTo(b *sql.Builder) {
+	if isJSONDialect(b.Dialect()) {
+		b.AddError(fmt.Errorf("hstore: casting the values to %s is not supported by %s", c.typ.name, b.Dialect()))
+		return
+	}
+
+	if c.guard {
+		b.WriteString("CASE WHEN ")
+		c.KeyValue.writeTo(b)
+		b.WriteString(" ~* ").WriteString(quoteKey(c.typ.pattern)).WriteString(" THEN ")
+	}
+
+	b.WriteString("(")
+	c.KeyValue.writeTo(b)
+	b.WriteString(")::").WriteString(c.typ.name)
+
+	if c.guard {
+		b.WriteString(" END")
+	}
+}
+
+func (v KeyValue) writeTo(b *sql.Builder) {
+	b.Ident(v.column).WriteString(" -> ").WriteString(quoteKey(v.key))
+}
@@ -0,0 +1,105 @@
+package enthstore
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCastValue(t *testing.T) {
+	t.Parallel()
+
+	where := func(p *sql.Predicate) sql.Querier {
+		return sql.Dialect(dialect.Postgres).
+			Select("*").
+			From(sql.Table("users")).
+			Where(p)
+	}
+
+	date := time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		input     sql.Querier
+		wantQuery string
+		wantArgs  []interface{}
+	}{
+		{
+			input:     where(Value("attributes", "age").As(Numeric).EQ(10)),
+			wantQuery: `SELECT * FROM "users" WHERE ("attributes" -> 'age')::numeric = $1`,
+			wantArgs:  []interface{}{10},
+		},
+		{
+			input:     where(Value("attributes", "age").As(Numeric).NEQ(10)),
+			wantQuery: `SELECT * FROM "users" WHERE ("attributes" -> 'age')::numeric <> $1`,
+			wantArgs:  []interface{}{10},
+		},
+		{
+			input:     where(Value("attributes", "age").As(Integer).GT(10)),
+			wantQuery: `SELECT * FROM "users" WHERE ("attributes" -> 'age')::bigint > $1`,
+			wantArgs:  []interface{}{10},
+		},
+		{
+			input:     where(Value("attributes", "age").As(Integer).GTE(10)),
+			wantQuery: `SELECT * FROM "users" WHERE ("attributes" -> 'age')::bigint >= $1`,
+			wantArgs:  []interface{}{10},
+		},
+		{
+			input:     where(Value("attributes", "born").As(Date).LT(date)),
+			wantQuery: `SELECT * FROM "users" WHERE ("attributes" -> 'born')::date < $1`,
+			wantArgs:  []interface{}{date},
+		},
+		{
+			input:     where(Value("attributes", "born").As(Timestamp).LTE(date)),
+			wantQuery: `SELECT * FROM "users" WHERE ("attributes" -> 'born')::timestamptz <= $1`,
+			wantArgs:  []interface{}{date},
+		},
+		{
+			input:     where(Value("attributes", "it's").As(Boolean).EQ(true)),
+			wantQuery: `SELECT * FROM "users" WHERE ("attributes" -> 'it''s')::boolean = $1`,
+			wantArgs:  []interface{}{true},
+		},
+		{
+			input:     where(Value("attributes", "age").As(Numeric).Between(1, 10)),
+			wantQuery: `SELECT * FROM "users" WHERE ("attributes" -> 'age')::numeric BETWEEN $1 AND $2`,
+			wantArgs:  []interface{}{1, 10},
+		},
+		{
+			input: where(Value("attributes", "age").As(Integer).Guard().GT(10)),
+			wantQuery: `SELECT * FROM "users" WHERE CASE WHEN "attributes" -> 'age' ~* '^\s*[-+]?\d{1,18}\s*$' ` +
+				`THEN ("attributes" -> 'age')::bigint END > $1`,
+			wantArgs: []interface{}{10},
+		},
+	}
+	for i, tt := range tests {
+		tt := tt
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+			query, args := tt.input.Query()
+			require.Equal(t, tt.wantQuery, query)
+			require.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestValueType_String(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "numeric", Numeric.String())
+	require.Equal(t, "timestamptz", Timestamp.String())
+}
+
+func TestCastValueJSON(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{dialect.SQLite, dialect.MySQL} {
+		s := sql.Dialect(name).
+			Select("*").
+			From(sql.Table("users")).
+			Where(Value("attributes", "age").As(Numeric).GT(18))
+		s.Query()
+		require.EqualError(t, s.Err(), "hstore: casting the values to numeric is not supported by "+name)
+	}
+}
@@ -0,0 +1,204 @@
+package enthstore
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strconv"
+	"strings"
+
+	atlas "ariga.io/atlas/sql/schema"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/schema"
+)
+
+// Checks returns the Postgres CHECK constraints enforcing the schema on the
+// given column, keyed by constraint name. The names end with a hash of the
+// expression, so a changed rule gets a new name and the migrations, which
+// pair the constraints by name, replace it instead of keeping the old one.
+// Like Validate, a NULL column satisfies all the constraints.
+//
+// The patterns are evaluated by Postgres as POSIX regular expressions,
+// the patterns must use the syntax shared with the Go regexp package.
+func (s Schema) Checks(column string) map[string]string {
+	checks := make(map[string]string)
+
+	var required, allowed []string
+	for key, rule := range s.Keys {
+		allowed = append(allowed, quoteKey(key))
+		if rule.Required {
+			required = append(required, quoteKey(key))
+		}
+
+		if expr := rule.checkExpr(column, key); expr != "" {
+			checks[checkName(column+"_key_"+key, expr)] = expr
+		}
+	}
+
+	sort.Strings(required)
+	sort.Strings(allowed)
+
+	if len(required) > 0 {
+		expr := checkExpr(func(b *sql.Builder) {
+			b.Ident(column).WriteString(" ?& ARRAY[").WriteString(strings.Join(required, ",")).WriteString("]")
+		})
+		checks[checkName(column+"_required", expr)] = expr
+	}
+
+	if !s.AllowUnknown {
+		expr := checkExpr(func(b *sql.Builder) {
+			b.WriteString("akeys(").Ident(column).WriteString(") <@ ARRAY[").
+				WriteString(strings.Join(allowed, ",")).WriteString("]::text[]")
+		})
+		checks[checkName(column+"_allowed_keys", expr)] = expr
+	}
+
+	if s.MaxKeys > 0 {
+		expr := checkExpr(func(b *sql.Builder) {
+			b.WriteString("cardinality(akeys(").Ident(column).WriteString(")) <= ").
+				WriteString(strconv.Itoa(s.MaxKeys))
+		})
+		checks[checkName(column+"_max_keys", expr)] = expr
+	}
+
+	return checks
+}
+
+// checkExpr returns the expression of the rules of the key, empty
+// when the rule has no constraints. A missing key satisfies the
+// expression, the required keys are checked by another constraint.
+func (r KeyRule) checkExpr(column string, key string) string {
+	value := checkExpr(func(b *sql.Builder) {
+		b.WriteString("(").Ident(column).WriteString(" -> ").WriteString(quoteKey(key)).WriteString(")")
+	})
+
+	var conds []string
+	if !r.Nullable {
+		conds = append(conds, checkExpr(func(b *sql.Builder) {
+			b.WriteString("(NOT exist(").Ident(column).Comma().WriteString(quoteKey(key)).
+				WriteString(") OR defined(").Ident(column).Comma().WriteString(quoteKey(key)).WriteString("))")
+		}))
+	}
+
+	if r.Pattern != nil {
+		conds = append(conds, value+" ~ "+quoteKey(r.Pattern.String()))
+	}
+
+	if len(r.Enum) > 0 {
+		values := make([]string, 0, len(r.Enum))
+		for _, v := range r.Enum {
+			values = append(values, quoteKey(v))
+		}
+
+		conds = append(conds, value+" IN ("+strings.Join(values, ", ")+")")
+	}
+
+	if r.Min != nil || r.Max != nil {
+		var cmp []string
+		if r.Min != nil {
+			cmp = append(cmp, value+"::numeric >= "+strconv.FormatFloat(*r.Min, 'g', -1, 64))
+		}
+
+		if r.Max != nil {
+			cmp = append(cmp, value+"::numeric <= "+strconv.FormatFloat(*r.Max, 'g', -1, 64))
+		}
+
+		// the CASE avoids casting values that aren't numeric,
+		// the NULL values are handled by the nullability constraint.
+		conds = append(conds, "CASE WHEN "+value+" ~* "+quoteKey(Numeric.pattern)+
+			" THEN "+strings.Join(cmp, " AND ")+" WHEN "+value+" IS NOT NULL THEN false END")
+	}
+
+	return strings.Join(conds, " AND ")
+}
+
+// checkExpr returns the SQL written by fn using the Postgres dialect.
+func checkExpr(fn func(b *sql.Builder)) string {
+	b := &sql.Builder{}
+	b.SetDialect(dialect.Postgres)
+	fn(b)
+
+	return b.String()
+}
+
+// maxNameLength is the length of the identifiers
+// kept by Postgres, longer identifiers are truncated.
+const maxNameLength = 63
+
+// checkName returns the name of the constraint of the expression, the
+// sanitized name followed by a hash of the original name and the
+// expression, so the names of different keys never collide and a
+// changed expression gets a new name.
+func checkName(name string, expr string) string {
+	return hashedName(sanitizeName(name), name+"\x00"+expr)
+}
+
+// sanitizeName replaces the characters other than
+// letters, digits and underscores with underscores.
+func sanitizeName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+
+		return '_'
+	}, name)
+}
+
+// hashedName returns the name followed by a hash of data,
+// truncating the name to fit in maxNameLength.
+func hashedName(name string, data string) string {
+	sum := sha256.Sum256([]byte(data))
+	suffix := "_" + hex.EncodeToString(sum[:4])
+	if len(name) > maxNameLength-len(suffix) {
+		name = name[:maxNameLength-len(suffix)]
+	}
+
+	return name + suffix
+}
+
+// ChecksOption returns a schema.MigrateOption that adds the CHECK constraints
+// of the schema on the column of the table. The constraints are added to the
+// desired state of the Atlas migrations, so they are created by Schema.Create
+// and emitted on the versioned migration files generated by Schema.Diff.
+//
+//	client.Schema.Create(ctx, enthstore.ChecksOption(user.Table, user.FieldAttributes, s))
+func ChecksOption(table string, column string, s Schema) schema.MigrateOption {
+	return schema.WithDiffHook(func(next schema.Differ) schema.Differ {
+		return schema.DiffFunc(func(current, desired *atlas.Schema) ([]atlas.Change, error) {
+			if t, found := desired.Table(table); found {
+				addChecks(t, s.Checks(column))
+			}
+
+			return next.Diff(current, desired)
+		})
+	})
+}
+
+// addChecks adds the checks to the table sorted by name,
+// replacing the checks with the same name.
+func addChecks(t *atlas.Table, checks map[string]string) {
+	attrs := t.Attrs[:0]
+	for _, attr := range t.Attrs {
+		if c, ok := attr.(*atlas.Check); ok {
+			if _, found := checks[c.Name]; found {
+				continue
+			}
+		}
+
+		attrs = append(attrs, attr)
+	}
+
+	t.Attrs = attrs
+
+	names := make([]string, 0, len(checks))
+	for name := range checks {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	for _, name := range names {
+		t.AddChecks(&atlas.Check{Name: name, Expr: checks[name]})
+	}
+}
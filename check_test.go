@@ -0,0 +1,71 @@
+package enthstore
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	atlas "ariga.io/atlas/sql/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchema_Checks(t *testing.T) {
+	t.Parallel()
+
+	s := Schema{
+		Keys: map[string]KeyRule{
+			"email": {Required: true, Pattern: regexp.MustCompile(`^\S+@\S+$`)},
+			"plan":  {Required: true, Enum: []string{"free", "pro"}, Nullable: true},
+			"age":   {Min: Float(0), Max: Float(150), Nullable: true},
+			"name":  {Nullable: true},
+			"it's":  {},
+		},
+		MaxKeys: 10,
+	}
+
+	require.Equal(t, map[string]string{
+		"attributes_required_3b3bdb0b":     `"attributes" ?& ARRAY['email','plan']`,
+		"attributes_allowed_keys_40c03ac1": `akeys("attributes") <@ ARRAY['age','email','it''s','name','plan']::text[]`,
+		"attributes_max_keys_18ec4031":     `cardinality(akeys("attributes")) <= 10`,
+		"attributes_key_email_d8015fd3": `(NOT exist("attributes", 'email') OR defined("attributes", 'email')) AND ` +
+			`("attributes" -> 'email') ~ '^\S+@\S+$'`,
+		"attributes_key_plan_2d6ffd6e": `("attributes" -> 'plan') IN ('free', 'pro')`,
+		"attributes_key_age_779a71e4": `CASE WHEN ("attributes" -> 'age') ~* '` + Numeric.pattern + `' ` +
+			`THEN ("attributes" -> 'age')::numeric >= 0 AND ("attributes" -> 'age')::numeric <= 150 ` +
+			`WHEN ("attributes" -> 'age') IS NOT NULL THEN false END`,
+		"attributes_key_it_s_630827ad": `(NOT exist("attributes", 'it''s') OR defined("attributes", 'it''s'))`,
+	}, s.Checks("attributes"))
+
+	require.Empty(t, Schema{AllowUnknown: true}.Checks("attributes"))
+}
+
+func TestCheckName(t *testing.T) {
+	t.Parallel()
+
+	long := "attributes_key_" + strings.Repeat("k", 60)
+	require.Equal(t, "attributes_key_name_8db17745", checkName("attributes_key_name", "true"))
+	require.Equal(t, "attributes_key_it_s_9c65cdd9", checkName("attributes_key_it's", "true"))
+	require.Equal(t, long[:54]+"_af65f2f3", checkName(long, "true"))
+	require.Len(t, checkName(long, "true"), 63)
+	require.NotEqual(t, checkName("attributes_key_a b", "true"), checkName("attributes_key_a_b", "true"))
+	require.NotEqual(t, checkName("attributes_key_a", "true"), checkName("attributes_key_a", "false"))
+}
+
+func TestAddChecks(t *testing.T) {
+	t.Parallel()
+
+	table := atlas.NewTable("users").AddChecks(
+		&atlas.Check{Name: "other", Expr: "true"},
+		&atlas.Check{Name: "attributes_required", Expr: "false"},
+	)
+	addChecks(table, map[string]string{
+		"attributes_required": "a",
+		"attributes_max_keys": "b",
+	})
+
+	require.Equal(t, []atlas.Attr{
+		&atlas.Check{Name: "other", Expr: "true"},
+		&atlas.Check{Name: "attributes_max_keys", Expr: "b"},
+		&atlas.Check{Name: "attributes_required", Expr: "a"},
+	}, table.Attrs)
+}
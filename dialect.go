@@ -0,0 +1,527 @@
+package enthstore
+
+import (
+	"context"
+	stdsql "database/sql"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+)
+
+// isJSONDialect reports whether the Hstore is stored as JSON on the dialect.
+func isJSONDialect(name string) bool {
+	return name == dialect.SQLite || name == dialect.MySQL
+}
+
+// JSONDriver wraps the driver of a dialect that stores the Hstore as JSON,
+// like SQLite and MySQL, encoding the Hstore and Typed arguments as JSON and
+// decoding the JSON columns scanned into them. The driver.Valuer of the types
+// is not aware of the dialect and uses the hstore text representation used
+// by Postgres, the placeholders written by FormatParam make the queries fail
+// when the text is written to a JSON column without the JSONDriver.
+//
+//	drv, err := sql.Open(dialect.SQLite, "file:ent?mode=memory&_fk=1")
+//	client := ent.NewClient(ent.Driver(enthstore.JSONDriver(drv)))
+func JSONDriver(drv dialect.Driver) dialect.Driver {
+	return &jsonDriver{Driver: drv}
+}
+
+type jsonDriver struct {
+	dialect.Driver
+}
+
+// Exec encodes the arguments and executes the query.
+func (d *jsonDriver) Exec(ctx context.Context, query string, args, v interface{}) error {
+	args, err := jsonArgs(d.Dialect(), args)
+	if err != nil {
+		return err
+	}
+
+	return d.Driver.Exec(ctx, query, args, v)
+}
+
+// Query encodes the arguments, executes the query and
+// decodes the JSON objects scanned into an Hstore or Typed.
+func (d *jsonDriver) Query(ctx context.Context, query string, args, v interface{}) error {
+	args, err := jsonArgs(d.Dialect(), args)
+	if err != nil {
+		return err
+	}
+
+	if err := d.Driver.Query(ctx, query, args, v); err != nil {
+		return err
+	}
+
+	return wrapRows(d.Dialect(), v)
+}
+
+// Tx starts a transaction that encodes the arguments.
+func (d *jsonDriver) Tx(ctx context.Context) (dialect.Tx, error) {
+	tx, err := d.Driver.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &jsonTx{Tx: tx, dialect: d.Dialect()}, nil
+}
+
+// BeginTx starts a transaction with options that encodes the arguments.
+func (d *jsonDriver) BeginTx(ctx context.Context, opts *stdsql.TxOptions) (dialect.Tx, error) {
+	drv, ok := d.Driver.(interface {
+		BeginTx(context.Context, *stdsql.TxOptions) (dialect.Tx, error)
+	})
+	if !ok {
+		return d.Tx(ctx)
+	}
+
+	tx, err := drv.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &jsonTx{Tx: tx, dialect: d.Dialect()}, nil
+}
+
+type jsonTx struct {
+	dialect.Tx
+	dialect string
+}
+
+// Exec encodes the arguments and executes the query.
+func (tx *jsonTx) Exec(ctx context.Context, query string, args, v interface{}) error {
+	args, err := jsonArgs(tx.dialect, args)
+	if err != nil {
+		return err
+	}
+
+	return tx.Tx.Exec(ctx, query, args, v)
+}
+
+// Query encodes the arguments, executes the query and
+// decodes the JSON objects scanned into an Hstore or Typed.
+func (tx *jsonTx) Query(ctx context.Context, query string, args, v interface{}) error {
+	args, err := jsonArgs(tx.dialect, args)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Tx.Query(ctx, query, args, v); err != nil {
+		return err
+	}
+
+	return wrapRows(tx.dialect, v)
+}
+
+// jsonArgs returns a copy of the arguments with the Hstore
+// and Typed values encoded as JSON objects.
+func jsonArgs(name string, args interface{}) (interface{}, error) {
+	values, ok := args.([]interface{})
+	if !ok || !isJSONDialect(name) {
+		return args, nil
+	}
+
+	var encoded []interface{}
+	for i, v := range values {
+		if !isHstoreValue(v) {
+			continue
+		}
+
+		h, err := toHstore(v)
+		if err != nil {
+			return nil, err
+		}
+
+		if encoded == nil {
+			encoded = append([]interface{}(nil), values...)
+		}
+
+		encoded[i] = nil
+		if h != nil {
+			b, err := json.Marshal(h)
+			if err != nil {
+				return nil, err
+			}
+
+			encoded[i] = string(b)
+		}
+	}
+
+	if encoded == nil {
+		return args, nil
+	}
+
+	return encoded, nil
+}
+
+// jsonScanner is implemented by the types that
+// decode the JSON objects stored by SQLite and MySQL.
+type jsonScanner interface {
+	scanJSON(value interface{}) error
+}
+
+// wrapRows makes the rows returned by a query decode the JSON objects
+// scanned into an Hstore or Typed. The rows are only wrapped when a column
+// is JSON, since the schema migration expects the *sql.Rows of the driver.
+func wrapRows(name string, v interface{}) error {
+	rows, ok := v.(*sql.Rows)
+	if !ok || rows.ColumnScanner == nil || !isJSONDialect(name) {
+		return nil
+	}
+
+	types, err := rows.ColumnTypes()
+	if err != nil {
+		rows.Close()
+		return err
+	}
+
+	for _, t := range types {
+		if strings.EqualFold(t.DatabaseTypeName(), "json") {
+			rows.ColumnScanner = &jsonRows{ColumnScanner: rows.ColumnScanner}
+			return nil
+		}
+	}
+
+	return nil
+}
+
+type jsonRows struct {
+	sql.ColumnScanner
+}
+
+// Scan copies the columns of the current row into the values,
+// the JSON objects are decoded by the Hstore and Typed values.
+func (r *jsonRows) Scan(dest ...interface{}) error {
+	var wrapped []interface{}
+	for i, v := range dest {
+		s, ok := v.(jsonScanner)
+		if !ok {
+			continue
+		}
+
+		if wrapped == nil {
+			wrapped = append([]interface{}(nil), dest...)
+		}
+
+		wrapped[i] = jsonValue{scanner: s}
+	}
+
+	if wrapped == nil {
+		return r.ColumnScanner.Scan(dest...)
+	}
+
+	return r.ColumnScanner.Scan(wrapped...)
+}
+
+// jsonValue implements the interface Scanner for a jsonScanner.
+type jsonValue struct {
+	scanner jsonScanner
+}
+
+// Scan implements the interface Scanner.
+func (v jsonValue) Scan(value interface{}) error {
+	return v.scanner.scanJSON(value)
+}
+
+// jsonPath returns the JSON path of the key, bound as an argument, since
+// MySQL reads the backslashes of the string literals as escapes. The quoted
+// labels of MySQL are JSON strings, the labels of SQLite have no escapes.
+func jsonPath(name string, key string) string {
+	if name == dialect.MySQL {
+		key = strings.ReplaceAll(key, `\`, `\\`)
+		key = strings.ReplaceAll(key, `"`, `\"`)
+	}
+
+	return `$."` + key + `"`
+}
+
+// writeValue writes the expression that reads the value of the key,
+// the JSON null values are read as NULL.
+func writeValue(b *sql.Builder, column string, key string) {
+	switch b.Dialect() {
+	case dialect.SQLite:
+		writeSQLiteJSON(b, "json_extract", "value", column, key)
+	case dialect.MySQL:
+		b.WriteString("JSON_UNQUOTE(NULLIF(JSON_EXTRACT(").Ident(column).Comma().Arg(jsonPath(b.Dialect(), key)).
+			WriteString("), CAST('null' AS JSON)))")
+	default:
+		b.Ident(column).WriteString(" -> ").WriteString(quoteKey(key))
+	}
+}
+
+// writeSQLiteJSON writes the call of the JSON function of SQLite on the
+// path of the key, the paths of SQLite don't support keys with double
+// quotes, so these keys read the column of json_each instead.
+func writeSQLiteJSON(b *sql.Builder, fn string, eachColumn string, column string, key string) {
+	if strings.Contains(key, `"`) {
+		b.WriteString("(SELECT json_each." + eachColumn + " FROM json_each(").Ident(column).
+			WriteString(") WHERE json_each.key = ").WriteString(quoteKey(key)).WriteString(")")
+		return
+	}
+
+	b.WriteString(fn + "(").Ident(column).Comma().Arg(jsonPath(b.Dialect(), key)).WriteString(")")
+}
+
+// writeJSONKeys writes the expression that checks the keys of a JSON column
+// joined by op, the empty list is written as empty. A NULL column is unknown,
+// like the hstore operators.
+func writeJSONKeys(b *sql.Builder, column string, keys []string, op string, empty string) {
+	b.WriteString("CASE WHEN ").Ident(column).WriteString(" IS NOT NULL THEN ")
+	if len(keys) == 0 {
+		b.WriteString(empty)
+	}
+
+	for i, key := range keys {
+		if i > 0 {
+			b.WriteString(op)
+		}
+
+		if b.Dialect() == dialect.MySQL {
+			b.WriteString("JSON_CONTAINS_PATH(").Ident(column).WriteString(", 'one', ").
+				Arg(jsonPath(b.Dialect(), key)).WriteString(")")
+			continue
+		}
+
+		writeSQLiteJSON(b, "json_type", "type", column, key)
+		b.WriteString(" IS NOT NULL")
+	}
+
+	b.WriteString(" END")
+}
+
+// writeJSONContains writes the expression that checks if the JSON
+// column contains the pairs of h, or is contained by h when reverse.
+func writeJSONContains(b *sql.Builder, column string, h Hstore, reverse bool) {
+	if b.Dialect() == dialect.MySQL {
+		doc, _ := json.Marshal(h)
+		if h == nil {
+			doc = []byte("{}")
+		}
+
+		b.WriteString("JSON_CONTAINS(")
+		if reverse {
+			b.WriteString("CAST(").Arg(string(doc)).WriteString(" AS JSON), ").Ident(column)
+		} else {
+			b.Ident(column).WriteString(", CAST(").Arg(string(doc)).WriteString(" AS JSON)")
+		}
+
+		b.WriteString(")")
+		return
+	}
+
+	keys := make([]string, 0, len(h))
+	for key := range h {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	b.WriteString("CASE WHEN ").Ident(column).WriteString(" IS NOT NULL THEN ")
+	if reverse {
+		writeSQLiteContainedBy(b, column, h, keys)
+	} else {
+		writeSQLiteContains(b, column, h, keys)
+	}
+
+	b.WriteString(" END")
+}
+
+func writeSQLiteContains(b *sql.Builder, column string, h Hstore, keys []string) {
+	if len(keys) == 0 {
+		b.WriteString("TRUE")
+	}
+
+	for i, key := range keys {
+		if i > 0 {
+			b.WriteString(" AND ")
+		}
+
+		b.WriteString("COALESCE(")
+		if v := h[key]; v != nil {
+			writeValue(b, column, key)
+			b.WriteOp(sql.OpEQ).Arg(*v)
+		} else {
+			writeSQLiteJSON(b, "json_type", "type", column, key)
+			b.WriteString(" = 'null'")
+		}
+
+		b.WriteString(", FALSE)")
+	}
+}
+
+func writeSQLiteContainedBy(b *sql.Builder, column string, h Hstore, keys []string) {
+	b.WriteString("NOT EXISTS (SELECT 1 FROM json_each(").Ident(column).WriteString(")")
+	if len(keys) > 0 {
+		quoted := make([]string, 0, len(keys))
+		for _, key := range keys {
+			quoted = append(quoted, quoteKey(key))
+		}
+
+		b.WriteString(" WHERE json_each.key NOT IN (").WriteString(strings.Join(quoted, ", ")).WriteString(")")
+		for _, key := range keys {
+			b.WriteString(" OR (json_each.key = ").WriteString(quoteKey(key)).WriteString(" AND ")
+			if v := h[key]; v != nil {
+				b.WriteString("(json_each.type <> 'text' OR json_each.value <> ").Arg(*v).WriteString(")")
+			} else {
+				b.WriteString("json_each.type <> 'null'")
+			}
+
+			b.WriteString(")")
+		}
+	}
+
+	b.WriteString(")")
+}
+
+// writeIn writes the expression that checks if the value of the key is in
+// the list using op, the empty list is written as empty.
+func writeIn(b *sql.Builder, column string, key string, vals []string, op sql.Op, empty string) {
+	if len(vals) == 0 {
+		b.WriteString(empty)
+		return
+	}
+
+	args := make([]interface{}, len(vals))
+	for i, v := range vals {
+		args[i] = v
+	}
+
+	writeValue(b, column, key)
+	b.WriteOp(op).Nested(func(b *sql.Builder) {
+		b.Args(args...)
+	})
+}
+
+// writeKeyCount writes the expression of the number of keys of the column,
+// the number of keys of a NULL column is NULL.
+func writeKeyCount(b *sql.Builder, column string) {
+	switch b.Dialect() {
+	case dialect.MySQL:
+		b.WriteString("JSON_LENGTH(").Ident(column).WriteString(")")
+	case dialect.SQLite:
+		b.WriteString("CASE WHEN ").Ident(column).WriteString(" IS NOT NULL THEN (SELECT count(*) FROM json_each(").
+			Ident(column).WriteString(")) END")
+	default:
+		b.WriteString("cardinality(akeys(").Ident(column).WriteString("))")
+	}
+}
+
+// writeLike writes the expression that matches the value of the key with
+// the LIKE pattern prefix+val+suffix. The LIKE of SQLite is case-insensitive,
+// so SQLite uses the instr and substr functions instead.
+func writeLike(b *sql.Builder, column string, key string, prefix, val, suffix string) {
+	if b.Dialect() != dialect.SQLite {
+		writeValue(b, column, key)
+		b.WriteOp(sql.OpLike).Arg(prefix + escapeLike(val) + suffix)
+		return
+	}
+
+	n := strconv.Itoa(utf8.RuneCountInString(val))
+	switch {
+	case prefix != "" && suffix != "":
+		b.WriteString("instr(")
+		writeValue(b, column, key)
+		b.Comma().Arg(val).WriteString(") > 0")
+	case suffix != "":
+		b.WriteString("substr(")
+		writeValue(b, column, key)
+		b.WriteString(", 1, " + n + ")").WriteOp(sql.OpEQ).Arg(val)
+	case val == "":
+		// a NULL value is unknown, like LIKE.
+		writeValue(b, column, key)
+		b.WriteOp(sql.OpEQ)
+		writeValue(b, column, key)
+	default:
+		b.WriteString("substr(")
+		writeValue(b, column, key)
+		b.WriteString(", -" + n + ")").WriteOp(sql.OpEQ).Arg(val)
+	}
+}
+
+// escapeLike escapes the wildcards of the LIKE operator.
+func escapeLike(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `%`, `\%`)
+	return strings.ReplaceAll(s, `_`, `\_`)
+}
+
+// writePattern writes the expression that matches the value of the key with
+// the LIKE pattern, prefixed by not. SQLite uses GLOB, its LIKE is case-insensitive.
+func writePattern(b *sql.Builder, column string, key string, pattern string, not string) {
+	writeValue(b, column, key)
+	if b.Dialect() == dialect.SQLite {
+		b.WriteString(" " + not + "GLOB ").Arg(likeToGlob(pattern))
+		return
+	}
+
+	b.WriteString(" " + not + "LIKE ").Arg(pattern)
+}
+
+// likeToGlob converts a LIKE pattern with the default escape
+// character to a GLOB pattern, quoting the GLOB wildcards.
+func likeToGlob(pattern string) string {
+	var sb strings.Builder
+	escaped := false
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			escaped = false
+			writeGlobLiteral(&sb, r)
+		case r == '\\':
+			escaped = true
+		case r == '%':
+			sb.WriteByte('*')
+		case r == '_':
+			sb.WriteByte('?')
+		default:
+			writeGlobLiteral(&sb, r)
+		}
+	}
+
+	return sb.String()
+}
+
+func writeGlobLiteral(sb *strings.Builder, r rune) {
+	if r == '*' || r == '?' || r == '[' {
+		sb.WriteString("[" + string(r) + "]")
+		return
+	}
+
+	sb.WriteRune(r)
+}
+
+// writeRegex writes the expression that matches the value of the key with the
+// regular expression. SQLite requires the application to register the
+// REGEXP function, the case-folding is written as the "(?i)" flag.
+func writeRegex(b *sql.Builder, column string, key string, pattern string, fold bool) {
+	switch b.Dialect() {
+	case dialect.MySQL:
+		mode := "'c'"
+		if fold {
+			mode = "'i'"
+		}
+
+		b.WriteString("REGEXP_LIKE(")
+		writeValue(b, column, key)
+		b.Comma().Arg(pattern).Comma().WriteString(mode + ")")
+	case dialect.SQLite:
+		if fold {
+			pattern = "(?i)" + pattern
+		}
+
+		writeValue(b, column, key)
+		b.WriteString(" REGEXP ").Arg(pattern)
+	default:
+		op := " ~ "
+		if fold {
+			op = " ~* "
+		}
+
+		writeValue(b, column, key)
+		b.WriteString(op).Arg(pattern)
+	}
+}
@@ -0,0 +1,341 @@
+package enthstore
+
+import (
+	"context"
+	stdsql "database/sql"
+	"strconv"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONPredicates(t *testing.T) {
+	t.Parallel()
+
+	h := Hstore{"b": nil, "a": ptrString("x")}
+	tests := []struct {
+		dialect   string
+		pred      *sql.Predicate
+		wantQuery string
+		wantArgs  []interface{}
+	}{
+		{
+			dialect:   dialect.SQLite,
+			pred:      HasKey("attributes", `a"b`),
+			wantQuery: "SELECT * FROM `users` WHERE CASE WHEN `attributes` IS NOT NULL THEN (SELECT json_each.type FROM json_each(`attributes`) WHERE json_each.key = 'a\"b') IS NOT NULL END",
+		},
+		{
+			dialect:   dialect.MySQL,
+			pred:      HasAllKeys("attributes", "a", "b"),
+			wantQuery: "SELECT * FROM `users` WHERE CASE WHEN `attributes` IS NOT NULL THEN JSON_CONTAINS_PATH(`attributes`, 'one', ?) AND JSON_CONTAINS_PATH(`attributes`, 'one', ?) END",
+			wantArgs:  []interface{}{`$."a"`, `$."b"`},
+		},
+		{
+			dialect:   dialect.SQLite,
+			pred:      HasAnyKeys("attributes"),
+			wantQuery: "SELECT * FROM `users` WHERE CASE WHEN `attributes` IS NOT NULL THEN FALSE END",
+		},
+		{
+			dialect:   dialect.SQLite,
+			pred:      NotHasKey("attributes", "a"),
+			wantQuery: "SELECT * FROM `users` WHERE NOT (CASE WHEN `attributes` IS NOT NULL THEN json_type(`attributes`, ?) IS NOT NULL END)",
+			wantArgs:  []interface{}{`$."a"`},
+		},
+		{
+			dialect: dialect.SQLite,
+			pred:    Contains("attributes", h),
+			wantQuery: "SELECT * FROM `users` WHERE CASE WHEN `attributes` IS NOT NULL THEN " +
+				"COALESCE(json_extract(`attributes`, ?) = ?, FALSE) AND " +
+				"COALESCE(json_type(`attributes`, ?) = 'null', FALSE) END",
+			wantArgs: []interface{}{`$."a"`, "x", `$."b"`},
+		},
+		{
+			dialect:   dialect.MySQL,
+			pred:      Contains("attributes", h),
+			wantQuery: "SELECT * FROM `users` WHERE JSON_CONTAINS(`attributes`, CAST(? AS JSON))",
+			wantArgs:  []interface{}{`{"a":"x","b":null}`},
+		},
+		{
+			dialect: dialect.SQLite,
+			pred:    ContainedBy("attributes", h),
+			wantQuery: "SELECT * FROM `users` WHERE CASE WHEN `attributes` IS NOT NULL THEN " +
+				"NOT EXISTS (SELECT 1 FROM json_each(`attributes`) WHERE json_each.key NOT IN ('a', 'b') " +
+				"OR (json_each.key = 'a' AND (json_each.type <> 'text' OR json_each.value <> ?)) " +
+				"OR (json_each.key = 'b' AND json_each.type <> 'null')) END",
+			wantArgs: []interface{}{"x"},
+		},
+		{
+			dialect:   dialect.SQLite,
+			pred:      ContainedBy("attributes", Hstore{}),
+			wantQuery: "SELECT * FROM `users` WHERE CASE WHEN `attributes` IS NOT NULL THEN NOT EXISTS (SELECT 1 FROM json_each(`attributes`)) END",
+		},
+		{
+			dialect:   dialect.MySQL,
+			pred:      ContainedBy("attributes", nil),
+			wantQuery: "SELECT * FROM `users` WHERE JSON_CONTAINS(CAST(? AS JSON), `attributes`)",
+			wantArgs:  []interface{}{`{}`},
+		},
+		{
+			dialect:   dialect.SQLite,
+			pred:      ValueIsNull("attributes", "a"),
+			wantQuery: "SELECT * FROM `users` WHERE (`attributes` IS NOT NULL AND json_extract(`attributes`, ?) IS NULL)",
+			wantArgs:  []interface{}{`$."a"`},
+		},
+		{
+			dialect:   dialect.MySQL,
+			pred:      ValueGTE("attributes", "a", "x"),
+			wantQuery: "SELECT * FROM `users` WHERE JSON_UNQUOTE(NULLIF(JSON_EXTRACT(`attributes`, ?), CAST('null' AS JSON))) >= ?",
+			wantArgs:  []interface{}{`$."a"`, "x"},
+		},
+		{
+			dialect:   dialect.MySQL,
+			pred:      ValueEQ("attributes", `a"b\c`, "x"),
+			wantQuery: "SELECT * FROM `users` WHERE JSON_UNQUOTE(NULLIF(JSON_EXTRACT(`attributes`, ?), CAST('null' AS JSON))) = ?",
+			wantArgs:  []interface{}{`$."a\"b\\c"`, "x"},
+		},
+		{
+			dialect:   dialect.MySQL,
+			pred:      ValueContains("attributes", "a", "x%"),
+			wantQuery: "SELECT * FROM `users` WHERE JSON_UNQUOTE(NULLIF(JSON_EXTRACT(`attributes`, ?), CAST('null' AS JSON))) LIKE ?",
+			wantArgs:  []interface{}{`$."a"`, `%x\%%`},
+		},
+		{
+			dialect:   dialect.SQLite,
+			pred:      ValueContains("attributes", "a", "x%"),
+			wantQuery: "SELECT * FROM `users` WHERE instr(json_extract(`attributes`, ?), ?) > 0",
+			wantArgs:  []interface{}{`$."a"`, "x%"},
+		},
+		{
+			dialect:   dialect.SQLite,
+			pred:      ValueHasPrefix("attributes", "a", "é_"),
+			wantQuery: "SELECT * FROM `users` WHERE substr(json_extract(`attributes`, ?), 1, 2) = ?",
+			wantArgs:  []interface{}{`$."a"`, "é_"},
+		},
+		{
+			dialect:   dialect.SQLite,
+			pred:      ValueHasSuffix("attributes", "a", "xy"),
+			wantQuery: "SELECT * FROM `users` WHERE substr(json_extract(`attributes`, ?), -2) = ?",
+			wantArgs:  []interface{}{`$."a"`, "xy"},
+		},
+		{
+			dialect:   dialect.SQLite,
+			pred:      ValueHasSuffix("attributes", "a", ""),
+			wantQuery: "SELECT * FROM `users` WHERE json_extract(`attributes`, ?) = json_extract(`attributes`, ?)",
+			wantArgs:  []interface{}{`$."a"`, `$."a"`},
+		},
+		{
+			dialect:   dialect.MySQL,
+			pred:      AnyValueEQ("attributes", "x"),
+			wantQuery: "SELECT * FROM `users` WHERE CASE WHEN `attributes` IS NOT NULL THEN COALESCE(JSON_CONTAINS(JSON_EXTRACT(`attributes`, '$.*'), JSON_QUOTE(?)), FALSE) END",
+			wantArgs:  []interface{}{"x"},
+		},
+		{
+			dialect:   dialect.SQLite,
+			pred:      AnyValueEQ("attributes", "x"),
+			wantQuery: "SELECT * FROM `users` WHERE CASE WHEN `attributes` IS NOT NULL THEN EXISTS (SELECT 1 FROM json_each(`attributes`) WHERE json_each.type = 'text' AND json_each.value = ?) END",
+			wantArgs:  []interface{}{"x"},
+		},
+		{
+			dialect:   dialect.MySQL,
+			pred:      AnyValueContains("attributes", "x_"),
+			wantQuery: "SELECT * FROM `users` WHERE JSON_SEARCH(`attributes`, 'one', ?) IS NOT NULL",
+			wantArgs:  []interface{}{`%x\_%`},
+		},
+		{
+			dialect:   dialect.SQLite,
+			pred:      AnyValueContains("attributes", "x_"),
+			wantQuery: "SELECT * FROM `users` WHERE EXISTS (SELECT 1 FROM json_each(`attributes`) WHERE instr(json_each.value, ?) > 0)",
+			wantArgs:  []interface{}{"x_"},
+		},
+		{
+			dialect:   dialect.MySQL,
+			pred:      KeyMatches("attributes", "^x"),
+			wantQuery: "SELECT * FROM `users` WHERE EXISTS (SELECT 1 FROM JSON_TABLE(JSON_KEYS(`attributes`), '$[*]' COLUMNS (k TEXT PATH '$')) AS jk WHERE REGEXP_LIKE(jk.k, ?, 'c'))",
+			wantArgs:  []interface{}{"^x"},
+		},
+		{
+			dialect:   dialect.SQLite,
+			pred:      IsEmpty("attributes"),
+			wantQuery: "SELECT * FROM `users` WHERE CASE WHEN `attributes` IS NOT NULL THEN (SELECT count(*) FROM json_each(`attributes`)) END = 0",
+		},
+		{
+			dialect:   dialect.MySQL,
+			pred:      KeyCountGTE("attributes", 2),
+			wantQuery: "SELECT * FROM `users` WHERE JSON_LENGTH(`attributes`) >= ?",
+			wantArgs:  []interface{}{2},
+		},
+		{
+			dialect:   dialect.SQLite,
+			pred:      ValueIn("attributes", "a", "x", "y"),
+			wantQuery: "SELECT * FROM `users` WHERE json_extract(`attributes`, ?) IN (?, ?)",
+			wantArgs:  []interface{}{`$."a"`, "x", "y"},
+		},
+		{
+			dialect:   dialect.SQLite,
+			pred:      ValueEqualFold("attributes", "a", "X"),
+			wantQuery: "SELECT * FROM `users` WHERE lower(json_extract(`attributes`, ?)) = ?",
+			wantArgs:  []interface{}{`$."a"`, "x"},
+		},
+		{
+			dialect:   dialect.MySQL,
+			pred:      ValueContainsFold("attributes", "a", "X%"),
+			wantQuery: "SELECT * FROM `users` WHERE JSON_UNQUOTE(NULLIF(JSON_EXTRACT(`attributes`, ?), CAST('null' AS JSON))) COLLATE utf8mb4_general_ci LIKE ?",
+			wantArgs:  []interface{}{`$."a"`, `%x\%%`},
+		},
+		{
+			dialect:   dialect.SQLite,
+			pred:      ValueContainsFold("attributes", "a", "X%"),
+			wantQuery: "SELECT * FROM `users` WHERE instr(lower(json_extract(`attributes`, ?)), ?) > 0",
+			wantArgs:  []interface{}{`$."a"`, "x%"},
+		},
+		{
+			dialect:   dialect.SQLite,
+			pred:      ValueNotLike("attributes", "a", `a%_\%*?[`),
+			wantQuery: "SELECT * FROM `users` WHERE json_extract(`attributes`, ?) NOT GLOB ?",
+			wantArgs:  []interface{}{`$."a"`, "a*?%[*][?][[]"},
+		},
+		{
+			dialect:   dialect.MySQL,
+			pred:      ValueRegexFold("attributes", "a", "^x"),
+			wantQuery: "SELECT * FROM `users` WHERE REGEXP_LIKE(JSON_UNQUOTE(NULLIF(JSON_EXTRACT(`attributes`, ?), CAST('null' AS JSON))), ?, 'i')",
+			wantArgs:  []interface{}{`$."a"`, "^x"},
+		},
+		{
+			dialect:   dialect.SQLite,
+			pred:      ValueRegexFold("attributes", "a", "^x"),
+			wantQuery: "SELECT * FROM `users` WHERE json_extract(`attributes`, ?) REGEXP ?",
+			wantArgs:  []interface{}{`$."a"`, "(?i)^x"},
+		},
+	}
+	for i, tt := range tests {
+		tt := tt
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+			query, args := sql.Dialect(tt.dialect).
+				Select("*").
+				From(sql.Table("users")).
+				Where(tt.pred).
+				Query()
+			require.Equal(t, tt.wantQuery, query)
+			require.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestValueSimilarToJSON(t *testing.T) {
+	t.Parallel()
+
+	s := sql.Dialect(dialect.MySQL).
+		Select("*").
+		From(sql.Table("users")).
+		Where(ValueSimilarTo("attributes", "a", "x%"))
+	s.Query()
+	require.EqualError(t, s.Err(), "hstore: SIMILAR TO is not supported by mysql")
+}
+
+func TestHstore_FormatParamJSON(t *testing.T) {
+	t.Parallel()
+
+	h := Hstore{"a": ptrString("b")}
+	query, _ := sql.Dialect(dialect.SQLite).
+		Update("users").
+		Set("attributes", &h).
+		Query()
+	require.Equal(t, "UPDATE `users` SET `attributes` = json(?)", query)
+
+	query, _ = sql.Dialect(dialect.MySQL).
+		Update("users").
+		Set("attributes", &h).
+		Query()
+	require.Equal(t, "UPDATE `users` SET `attributes` = CAST(? AS JSON)", query)
+	require.Equal(t, map[string]string{
+		dialect.Postgres: "hstore",
+		dialect.SQLite:   "json",
+		dialect.MySQL:    "json",
+	}, Hstore{}.SchemaType())
+}
+
+func TestHstore_ScanJSON(t *testing.T) {
+	t.Parallel()
+
+	var h Hstore
+	rows := &jsonRows{ColumnScanner: &fakeRows{values: []interface{}{[]byte(` {"a": "b", "c": null}`), "x"}}}
+	var s stdsql.NullString
+	require.NoError(t, rows.Scan(&h, &s))
+	require.Equal(t, Hstore{"a": ptrString("b"), "c": nil}, h)
+	require.Equal(t, "x", s.String)
+
+	require.NoError(t, h.scanJSON(`{"d": "e"}`))
+	require.Equal(t, Hstore{"a": ptrString("b"), "c": nil, "d": ptrString("e")}, h)
+
+	require.Error(t, h.scanJSON(`{"a": 1}`))
+
+	var typed Typed[typedStruct]
+	rows = &jsonRows{ColumnScanner: &fakeRows{values: []interface{}{`{"name": "a"}`}}}
+	require.NoError(t, rows.Scan(&typed))
+	require.Equal(t, "a", typed.Data.Name)
+
+	// the JSON objects scanned without the JSONDriver are decoded as well.
+	h = nil
+	require.NoError(t, h.Scan(`{"a": "b", "c": null}`))
+	require.Equal(t, Hstore{"a": ptrString("b"), "c": nil}, h)
+	require.Error(t, h.Scan(`{"a": 1}`))
+
+	typed = Typed[typedStruct]{}
+	require.NoError(t, typed.Scan([]byte(`{"name": "b"}`)))
+	require.Equal(t, "b", typed.Data.Name)
+
+	// the text representation is never decoded as JSON.
+	h = nil
+	require.NoError(t, h.Scan("{a}=>b"))
+	require.Equal(t, Hstore{"{a}": ptrString("b")}, h)
+}
+
+// fakeRows scans the values of a single row.
+type fakeRows struct {
+	sql.ColumnScanner
+	values []interface{}
+}
+
+func (r *fakeRows) Scan(dest ...interface{}) error {
+	for i, v := range dest {
+		if err := v.(stdsql.Scanner).Scan(r.values[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fakeDriver records the arguments of the queries.
+type fakeDriver struct {
+	dialect.Driver
+	name string
+	args []interface{}
+}
+
+func (d *fakeDriver) Dialect() string { return d.name }
+
+func (d *fakeDriver) Exec(_ context.Context, _ string, args, _ interface{}) error {
+	d.args, _ = args.([]interface{})
+	return nil
+}
+
+func TestJSONDriver(t *testing.T) {
+	t.Parallel()
+
+	h := Hstore{"a": ptrString("b")}
+	typed := NewTyped(typedStruct{Name: "a"})
+	args := []interface{}{1, h, &h, (*Hstore)(nil), Hstore(nil), typed, &typed}
+
+	drv := &fakeDriver{name: dialect.SQLite}
+	require.NoError(t, JSONDriver(drv).Exec(context.Background(), "", args, nil))
+	require.Equal(t, []interface{}{1, `{"a":"b"}`, `{"a":"b"}`, nil, nil, `{"email":null,"name":"a"}`, `{"email":null,"name":"a"}`}, drv.args)
+	require.Equal(t, h, args[1], "the arguments must not be changed")
+
+	drv = &fakeDriver{name: dialect.Postgres}
+	require.NoError(t, JSONDriver(drv).Exec(context.Background(), "", args, nil))
+	require.Equal(t, args, drv.args)
+}
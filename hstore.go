@@ -1,12 +1,12 @@
 package enthstore
 
 import (
-	"bytes"
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 
 	"entgo.io/ent/dialect"
@@ -105,127 +105,110 @@ func (h Hstore) String() string {
 }
 
 // Scan implements the interface Scanner.
+//
+// Scan is lenient, the syntax errors are ignored and the
+// malformed pairs are read as well as possible, like "c"
+// of "a=>b, c" with an empty value, use ScanStrict to
+// report malformed input. The JSON
+// objects stored by SQLite and MySQL are decoded
+// as JSON, see isJSONObject.
 func (h *Hstore) Scan(value interface{}) error {
 	if value == nil {
 		return nil
 	}
 
-	if *h == nil {
-		*h = Hstore{}
+	input, err := scanInput(value)
+	if err != nil {
+		return err
 	}
 
-	var input string
+	if isJSONObject(input) {
+		return h.scanJSON(input)
+	}
 
-	switch v := value.(type) {
-	case string:
-		input = v
-	case []byte:
-		input = string(v)
-	default:
-		return fmt.Errorf("invalid input type: %T", v)
+	if *h == nil {
+		*h = make(Hstore, countPairs(input))
 	}
 
-	input = strings.TrimSpace(input)
+	p := parser{input: input}
+	return p.parse(*h)
+}
 
-	if len(input) == 0 {
-		return nil
+// Value implements the interface driver.Valuer.
+//
+// The pairs are encoded in canonical order, see Canonical. A nil Hstore
+// is encoded as NULL and an empty Hstore as an empty string, they
+// are told apart by IsEmpty, which is unknown for NULL.
+func (h Hstore) Value() (driver.Value, error) {
+	if h == nil {
+		return nil, nil
 	}
 
-	record := [][]byte{{}, {}}
-	cur := 0
-	isEscaping := false
-	insideQuote := false
-	lastQuoted := false
-	ignore := []byte{' ', '\t', '\n', '\r', '='}
+	return h.Canonical(), nil
+}
 
-	savePair := func() {
-		if len(record) == 0 {
-			return
+// Canonical returns the text representation of the Hstore with the
+// pairs sorted the way Postgres stores them, by key length and then
+// by the key bytes, so the same Hstore always produces the same text.
+func (h Hstore) Canonical() string {
+	var sb strings.Builder
+	for i, key := range h.Keys() {
+		if i > 0 {
+			sb.WriteString(",")
 		}
 
-		key := string(record[0])
-		value := string(record[1])
+		sb.WriteString(quoteValue(key))
+		sb.WriteString("=>")
 
-		if !lastQuoted && strings.ToUpper(value) == "NULL" {
-			h.Set(key, nil)
+		if val := h[key]; val == nil {
+			sb.WriteString("NULL")
 		} else {
-			h.SetString(key, value)
+			sb.WriteString(quoteValue(*val))
 		}
-
-		record[0] = []byte{}
-		record[1] = []byte{}
-		cur = 0
 	}
 
-	for _, c := range []byte(input) {
-		if isEscaping {
-			isEscaping = false
-			record[cur] = append(record[cur], c)
-			continue
-		}
-
-		if c == '\\' {
-			isEscaping = true
-			continue
-		}
-
-		if c == '"' {
-			insideQuote = !insideQuote
-			if !insideQuote {
-				lastQuoted = true
-			}
-			continue
-		}
-
-		if insideQuote {
-			record[cur] = append(record[cur], c)
-			continue
-		}
-
-		if bytes.IndexByte(ignore, c) != -1 {
-			continue
-		}
+	return sb.String()
+}
 
-		if c == '>' {
-			cur++
-			lastQuoted = false
-			continue
-		}
+// Keys returns the keys of the Hstore in canonical order,
+// by key length and then by the key bytes, see Canonical.
+func (h Hstore) Keys() []string {
+	keys := make([]string, 0, len(h))
+	for key := range h {
+		keys = append(keys, key)
+	}
 
-		if c == ',' {
-			savePair()
-			continue
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) < len(keys[j])
 		}
 
-		record[cur] = append(record[cur], c)
-	}
+		return keys[i] < keys[j]
+	})
 
-	savePair()
-	return nil
+	return keys
 }
 
-// Value implements the interface driver.Valuer.
-func (h Hstore) Value() (driver.Value, error) {
-	if h == nil {
-		return nil, nil
-	}
+// FormatParam defines how format the placeholder, the
+// dialects that store the Hstore as JSON validate the JSON.
+func (Hstore) FormatParam(param string, info *sql.StmtInfo) string {
+	return formatParam(param, info)
+}
 
-	parts := make([]string, 0, len(h))
-	for key, val := range h {
-		var part string
-		if val == nil {
-			part = quoteValue(key) + "=>NULL"
-		} else {
-			part = quoteValue(key) + "=>" + quoteValue(*val)
+// formatParam casts the placeholder to hstore, on the dialects that store
+// the Hstore as JSON the placeholder is parsed as JSON instead, so the
+// hstore text written by Value without the JSONDriver fails the query
+// instead of being stored on the column.
+func formatParam(param string, info *sql.StmtInfo) string {
+	if info != nil {
+		switch info.Dialect {
+		case dialect.SQLite:
+			return "json(" + param + ")"
+		case dialect.MySQL:
+			return "CAST(" + param + " AS JSON)"
 		}
-
-		parts = append(parts, part)
 	}
-	return strings.Join(parts, ","), nil
-}
 
-// FormatParam defines how format the placeholder.
-func (h *Hstore) FormatParam(param string, info *sql.StmtInfo) string {
 	return param + "::hstore"
 }
 
@@ -282,9 +265,12 @@ func (h Hstore) Equals(other Hstore) bool {
 	return true
 }
 
-// SchemaType defines the schema-type of the Hstore object.
+// SchemaType defines the schema-type of the Hstore object,
+// SQLite and MySQL store the Hstore as a JSON object, see JSONDriver.
 func (Hstore) SchemaType() map[string]string {
 	return map[string]string{
 		dialect.Postgres: "hstore",
+		dialect.SQLite:   "json",
+		dialect.MySQL:    "json",
 	}
 }
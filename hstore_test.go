@@ -2,6 +2,7 @@ package enthstore
 
 import (
 	"bytes"
+	"errors"
 	"strconv"
 	"strings"
 	"testing"
@@ -104,6 +105,14 @@ func TestHstore_Scan(t *testing.T) {
 		{args: `aa=>NuLl`, wantHstore: Hstore{"aa": nil}, wantErr: nil},
 		{args: `aa=>"NuLl"`, wantHstore: FromMap(map[string]string{"aa": "NuLl"}), wantErr: nil},
 		{args: `	`, wantHstore: nil, wantErr: nil},
+		{args: []byte(`"a"=>"b"`), wantHstore: FromMap(map[string]string{"a": "b"}), wantErr: nil},
+		{args: `"a\"b"=>"c\\d"`, wantHstore: FromMap(map[string]string{`a"b`: `c\d`}), wantErr: nil},
+		{args: `a=>b, c`, wantHstore: FromMap(map[string]string{"a": "b", "c": ""}), wantErr: nil},
+		{args: `a=>b, "c=>d`, wantHstore: FromMap(map[string]string{"a": "b", "c=>d": ""}), wantErr: nil},
+		{args: `a=>b c, d=>NULL`, wantHstore: Hstore{"a": ptrString("bc"), "d": nil}, wantErr: nil},
+		{args: `a=>b=>c, d=>e`, wantHstore: FromMap(map[string]string{"a": "b>c", "d": "e"}), wantErr: nil},
+		{args: `a=>b, c=>"d`, wantHstore: FromMap(map[string]string{"a": "b", "c": "d"}), wantErr: nil},
+		{args: 1, wantHstore: nil, wantErr: errors.New("invalid input type: int")},
 	}
 	for i, tt := range tests {
 		tt := tt
@@ -116,23 +125,106 @@ func TestHstore_Scan(t *testing.T) {
 	}
 }
 
+func benchmarkInput(pairs int, escaped bool) []byte {
+	hs := Hstore{}
+	for i := 0; i < pairs; i++ {
+		val := "value " + strconv.Itoa(i)
+		if escaped {
+			val = `"quoted" \\ ` + val
+		}
+
+		hs.SetString("key"+strconv.Itoa(i), val)
+	}
+	hs.Set("null", nil)
+
+	return []byte(hs.String())
+}
+
+func benchmarkScan(b *testing.B, pairs int, escaped bool) {
+	b.Helper()
+
+	input := benchmarkInput(pairs, escaped)
+	allocs := testing.AllocsPerRun(100, func() {
+		var hs Hstore
+		_ = hs.Scan(input)
+	})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var hs Hstore
+		if err := hs.Scan(input); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	b.ReportMetric(allocs/float64(pairs+1), "allocs/pair")
+}
+
+func BenchmarkHstore_Scan(b *testing.B) {
+	b.Run("10 pairs", func(b *testing.B) { benchmarkScan(b, 10, false) })
+	b.Run("50 pairs", func(b *testing.B) { benchmarkScan(b, 50, false) })
+	b.Run("50 pairs escaped", func(b *testing.B) { benchmarkScan(b, 50, true) })
+	b.Run("500 pairs", func(b *testing.B) { benchmarkScan(b, 500, false) })
+}
+
 func TestHstore_String(t *testing.T) {
 	h1 := FromMap(map[string]string{
 		"key1": "value1",
 		"key2": "value2",
 	})
 
-	res1 := h1.String()
-	require.True(t, res1 == `"key1"=>"value1","key2"=>"value2"` ||
-		res1 == `"key2"=>"value2","key1"=>"value1"`)
+	require.Equal(t, `"key1"=>"value1","key2"=>"value2"`, h1.String())
 
 	h2 := Hstore{}
 	h2.SetString("key1", "value1")
 	h2.Set("key2", nil)
 
-	res2 := h2.String()
-	require.True(t, res2 == `"key1"=>"value1","key2"=>NULL` ||
-		res2 == `"key2"=>NULL,"key1"=>"value1"`)
+	require.Equal(t, `"key1"=>"value1","key2"=>NULL`, h2.String())
+}
+
+func TestHstore_Canonical(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		args Hstore
+		want string
+	}{
+		{args: nil, want: ""},
+		{args: Hstore{}, want: ""},
+		{args: FromMap(map[string]string{"b": "1", "a": "2"}), want: `"a"=>"2","b"=>"1"`},
+		{args: FromMap(map[string]string{"aa": "1", "b": "2", "": "3"}), want: `""=>"3","b"=>"2","aa"=>"1"`},
+		{args: FromMap(map[string]string{"B": "1", "a": "2", "ab": "3", "Ab": "4"}), want: `"B"=>"1","a"=>"2","Ab"=>"4","ab"=>"3"`},
+		{args: Hstore{"k": nil, `"q"`: nil}, want: `"k"=>NULL,"\"q\""=>NULL`},
+	}
+	for i, tt := range tests {
+		tt := tt
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+			for n := 0; n < 10; n++ {
+				require.Equal(t, tt.want, tt.args.Canonical())
+			}
+		})
+	}
+}
+
+func TestHstore_Keys(t *testing.T) {
+	t.Parallel()
+
+	require.Empty(t, Hstore(nil).Keys())
+	require.Equal(t, []string{"", "B", "a", "Ab", "ab"}, Hstore{"ab": nil, "a": nil, "Ab": nil, "B": nil, "": nil}.Keys())
+}
+
+func TestHstore_Value(t *testing.T) {
+	t.Parallel()
+
+	v, err := Hstore(nil).Value()
+	require.NoError(t, err)
+	require.Nil(t, v)
+
+	v, err = FromMap(map[string]string{"zz": "1", "a": "2", "b": "3"}).Value()
+	require.NoError(t, err)
+	require.Equal(t, `"a"=>"2","b"=>"3","zz"=>"1"`, v)
 }
 
 func TestHstore_UnmarshalGQL(t *testing.T) {
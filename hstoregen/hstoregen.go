@@ -0,0 +1,243 @@
+// Package hstoregen provides an entc.Extension that generates typed
+// predicates for the fields of type enthstore.Hstore:
+//
+//	err := entc.Generate("./schema", &gen.Config{}, entc.Extensions(hstoregen.NewExtension()))
+//
+// For a field named "attributes" on the User schema, predicates like
+// user.AttributesHasKey("a") and user.AttributesValueEQ("k", "v") are
+// generated in the where.go file of the user package.
+//
+// The extension also allows using enthstore.Typed fields, the generic
+// types are not supported by the code generation of ent, the extension
+// rewrites the type of those fields and imports the packages of the
+// type arguments.
+package hstoregen
+
+import (
+	"embed"
+	"path"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"entgo.io/ent/entc"
+	"entgo.io/ent/entc/gen"
+	"github.com/crossworth/enthstore"
+)
+
+var (
+	//go:embed template
+	templateDir embed.FS
+
+	// WhereTemplate is the template that generates the predicates.
+	WhereTemplate = gen.MustParse(gen.NewTemplate("where/additional/hstore").
+			Funcs(funcMap).
+			ParseFS(templateDir, "template/where.tmpl"))
+
+	// ImportTemplate is the template that imports the
+	// packages of the type arguments of enthstore.Typed fields.
+	ImportTemplate = gen.MustParse(gen.NewTemplate("import/additional/hstore").
+			Funcs(funcMap).
+			ParseFS(templateDir, "template/import.tmpl"))
+
+	funcMap = template.FuncMap{
+		"isHstore":         isHstore,
+		"hstorePredicates": hstorePredicates,
+		"typedImports":     typedImports,
+	}
+
+	hstoreType = reflect.TypeOf(enthstore.Hstore{})
+	typedType  = reflect.TypeOf(enthstore.Typed[struct{}]{})
+
+	// typedAnnotation is the field annotation holding the
+	// packages of the type arguments of enthstore.Typed fields.
+	typedAnnotation = "EntHstoreTyped"
+
+	// qualifiedIdent matches the package qualified
+	// identifiers on the string form of a type.
+	qualifiedIdent = regexp.MustCompile(`[\w./~-]+`)
+)
+
+// hstorePredicate describes a predicate of the enthstore package
+// that is generated for every Hstore field.
+type hstorePredicate struct {
+	// Name of the predicate, the generated function
+	// is prefixed with the name of the field.
+	Name string
+	// Params is the parameter list of the generated function,
+	// without the column.
+	Params string
+	// Args is the argument list passed after the column.
+	Args string
+}
+
+var predicates = []hstorePredicate{
+	{Name: "HasKey", Params: "key string", Args: "key"},
+	{Name: "HasAllKeys", Params: "keys ...string", Args: "keys..."},
+	{Name: "HasAnyKeys", Params: "keys ...string", Args: "keys..."},
+	{Name: "NotHasKey", Params: "key string", Args: "key"},
+	{Name: "Contains", Params: "h enthstore.Hstore", Args: "h"},
+	{Name: "NotContains", Params: "h enthstore.Hstore", Args: "h"},
+	{Name: "ContainedBy", Params: "h enthstore.Hstore", Args: "h"},
+	{Name: "NotContainedBy", Params: "h enthstore.Hstore", Args: "h"},
+	{Name: "ValueIsNull", Params: "key string", Args: "key"},
+	{Name: "ValueEQ", Params: "key, val string", Args: "key, val"},
+	{Name: "ValueNEQ", Params: "key, val string", Args: "key, val"},
+	{Name: "ValueGT", Params: "key, val string", Args: "key, val"},
+	{Name: "ValueGTE", Params: "key, val string", Args: "key, val"},
+	{Name: "ValueLT", Params: "key, val string", Args: "key, val"},
+	{Name: "ValueLTE", Params: "key, val string", Args: "key, val"},
+	{Name: "ValueIn", Params: "key string, vals ...string", Args: "key, vals..."},
+	{Name: "ValueNotIn", Params: "key string, vals ...string", Args: "key, vals..."},
+	{Name: "ValueContains", Params: "key, val string", Args: "key, val"},
+	{Name: "ValueHasPrefix", Params: "key, val string", Args: "key, val"},
+	{Name: "ValueHasSuffix", Params: "key, val string", Args: "key, val"},
+	{Name: "ValueEqualFold", Params: "key, val string", Args: "key, val"},
+	{Name: "ValueContainsFold", Params: "key, val string", Args: "key, val"},
+	{Name: "ValueLike", Params: "key, pattern string", Args: "key, pattern"},
+	{Name: "ValueNotLike", Params: "key, pattern string", Args: "key, pattern"},
+	{Name: "ValueRegex", Params: "key, pattern string", Args: "key, pattern"},
+	{Name: "ValueRegexFold", Params: "key, pattern string", Args: "key, pattern"},
+	{Name: "ValueSimilarTo", Params: "key, pattern string", Args: "key, pattern"},
+	{Name: "AnyValueEQ", Params: "val string", Args: "val"},
+	{Name: "AnyValueContains", Params: "val string", Args: "val"},
+	{Name: "KeyMatches", Params: "pattern string", Args: "pattern"},
+	{Name: "IsEmpty"},
+	{Name: "IsNotEmpty"},
+	{Name: "KeyCountEQ", Params: "n int", Args: "n"},
+	{Name: "KeyCountNEQ", Params: "n int", Args: "n"},
+	{Name: "KeyCountGT", Params: "n int", Args: "n"},
+	{Name: "KeyCountGTE", Params: "n int", Args: "n"},
+	{Name: "KeyCountLT", Params: "n int", Args: "n"},
+	{Name: "KeyCountLTE", Params: "n int", Args: "n"},
+}
+
+// Extension is an entc.Extension that generates
+// typed predicates for the Hstore fields.
+type Extension struct {
+	entc.DefaultExtension
+}
+
+// NewExtension creates a new Extension.
+func NewExtension() *Extension {
+	return &Extension{}
+}
+
+// Templates implements the interface entc.Extension.
+func (*Extension) Templates() []*gen.Template {
+	return []*gen.Template{WhereTemplate, ImportTemplate}
+}
+
+// Hooks implements the interface entc.Extension.
+func (*Extension) Hooks() []gen.Hook {
+	return []gen.Hook{typedHook}
+}
+
+// typedHook rewrites the type of enthstore.Typed fields, the string form
+// of generic types contains the full import path of the type arguments,
+// for example enthstore.Typed[example.com/schema.Attributes], the path is
+// replaced by the package name and stored in the field annotations.
+func typedHook(next gen.Generator) gen.Generator {
+	return gen.GenerateFunc(func(g *gen.Graph) error {
+		for _, n := range g.Nodes {
+			for _, f := range n.Fields {
+				if isTyped(f) {
+					rewriteTyped(f)
+				}
+			}
+		}
+
+		return next.Generate(g)
+	})
+}
+
+func rewriteTyped(f *gen.Field) {
+	var imports []string
+	ident := qualifiedIdent.ReplaceAllStringFunc(f.Type.RType.Ident, func(ident string) string {
+		pkg, name := splitIdent(ident)
+		if pkg == "" {
+			return ident
+		}
+
+		imports = append(imports, pkg)
+		return path.Base(pkg) + "." + name
+	})
+
+	f.Type.Ident = ident
+	f.Type.PkgName = path.Base(f.Type.PkgPath)
+	f.Type.RType.Ident = ident
+
+	if f.Annotations == nil {
+		f.Annotations = make(map[string]interface{})
+	}
+
+	f.Annotations[typedAnnotation] = imports
+}
+
+// typedImports returns the packages of the type arguments of
+// the enthstore.Typed fields of a type or of the whole graph.
+func typedImports(v interface{}) []string {
+	var fields []*gen.Field
+	switch v := v.(type) {
+	case *gen.Type:
+		fields = v.Fields
+	case *gen.Graph:
+		for _, n := range v.Nodes {
+			fields = append(fields, n.Fields...)
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, f := range fields {
+		imports, _ := f.Annotations[typedAnnotation].([]string)
+		for _, pkg := range imports {
+			seen[pkg] = true
+		}
+	}
+
+	imports := make([]string, 0, len(seen))
+	for pkg := range seen {
+		imports = append(imports, pkg)
+	}
+
+	sort.Strings(imports)
+	return imports
+}
+
+// splitIdent splits a qualified identifier in the import
+// path and the name, the path is only returned when it
+// is a full import path and not a package name.
+func splitIdent(ident string) (string, string) {
+	i := strings.LastIndex(ident, ".")
+	if i < 0 || !strings.Contains(ident[:i], "/") {
+		return "", ident
+	}
+
+	return ident[:i], ident[i+1:]
+}
+
+func hstorePredicates() []hstorePredicate {
+	return predicates
+}
+
+// isHstore reports whether the field is of type
+// enthstore.Hstore or enthstore.Typed.
+func isHstore(f *gen.Field) bool {
+	if f.Type == nil || f.Type.RType == nil {
+		return false
+	}
+
+	return f.Type.RType.PkgPath == hstoreType.PkgPath() && f.Type.RType.Name == hstoreType.Name() || isTyped(f)
+}
+
+// isTyped reports whether the field is of type enthstore.Typed.
+func isTyped(f *gen.Field) bool {
+	if f.Type == nil || f.Type.RType == nil {
+		return false
+	}
+
+	name, _, _ := strings.Cut(typedType.Name(), "[")
+	return f.Type.RType.PkgPath == typedType.PkgPath() && strings.HasPrefix(f.Type.RType.Name, name+"[")
+}
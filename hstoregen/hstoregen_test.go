@@ -0,0 +1,83 @@
+package hstoregen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"entgo.io/ent"
+	"entgo.io/ent/entc/gen"
+	"entgo.io/ent/entc/load"
+	"entgo.io/ent/schema/field"
+	"github.com/crossworth/enthstore"
+	"github.com/stretchr/testify/require"
+)
+
+type Settings struct {
+	Theme string `hstore:"theme"`
+}
+
+type User struct {
+	ent.Schema
+}
+
+func (User) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name"),
+		field.Other("attributes", enthstore.Hstore{}).
+			SchemaType(enthstore.Hstore{}.SchemaType()),
+		field.Other("settings", enthstore.Typed[Settings]{}).
+			SchemaType(enthstore.Typed[Settings]{}.SchemaType()),
+	}
+}
+
+func TestExtension(t *testing.T) {
+	buf, err := load.MarshalSchema(User{})
+	require.NoError(t, err)
+
+	schema, err := load.UnmarshalSchema(buf)
+	require.NoError(t, err)
+
+	storage, err := gen.NewStorage("sql")
+	require.NoError(t, err)
+
+	target := t.TempDir()
+	graph, err := gen.NewGraph(&gen.Config{
+		Storage:   storage,
+		Package:   "example.com/ent",
+		Target:    target,
+		Templates: NewExtension().Templates(),
+		Hooks:     NewExtension().Hooks(),
+	}, schema)
+	require.NoError(t, err)
+	require.NoError(t, graph.Gen())
+
+	where, err := os.ReadFile(filepath.Join(target, "user", "where.go"))
+	require.NoError(t, err)
+
+	require.Contains(t, string(where), `// AttributesHasKey applies the enthstore.HasKey predicate on the "attributes" field.
+func AttributesHasKey(key string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.HasKey(s.C(FieldAttributes), key))
+	})
+}`)
+	require.Contains(t, string(where), `func AttributesHasAllKeys(keys ...string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.HasAllKeys(s.C(FieldAttributes), keys...))
+	})
+}`)
+	require.Contains(t, string(where), `func AttributesValueEQ(key, val string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.ValueEQ(s.C(FieldAttributes), key, val))
+	})
+}`)
+	require.Contains(t, string(where), `func SettingsHasKey(key string) predicate.User {`)
+	require.NotContains(t, string(where), "NameHasKey")
+
+	entity, err := os.ReadFile(filepath.Join(target, "user.go"))
+	require.NoError(t, err)
+
+	require.Contains(t, string(entity), `hstoregen "github.com/crossworth/enthstore/hstoregen"`)
+	require.Contains(t, string(entity), "Settings enthstore.Typed[hstoregen.Settings] `json:\"settings,omitempty\"`")
+	require.Contains(t, string(entity), "values[i] = new(enthstore.Typed[hstoregen.Settings])")
+}
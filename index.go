@@ -0,0 +1,205 @@
+package enthstore
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"ariga.io/atlas/sql/postgres"
+	atlas "ariga.io/atlas/sql/schema"
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql/schema"
+	entschema "entgo.io/ent/schema"
+)
+
+// IndexMethod is the access method of an index on the whole hstore column.
+type IndexMethod string
+
+const (
+	// GIN indexes the keys, it's used by the key existence and containment operators.
+	GIN IndexMethod = "GIN"
+	// GiST indexes the pairs, it's used by the same operators of GIN and it's
+	// smaller and faster to update, but slower to query.
+	GiST IndexMethod = "GIST"
+)
+
+// IndexAnnotation is the field annotation that defines the indexes
+// of an Hstore field, the indexes are created by the IndexesOption.
+type IndexAnnotation struct {
+	// Methods holds the access methods of the indexes on the column.
+	Methods []IndexMethod `json:"methods,omitempty"`
+	// Keys holds the keys of the btree expression indexes on the values.
+	Keys []string `json:"keys,omitempty"`
+	// Values creates a GIN index on the array of values, used by AnyValueEQ.
+	Values bool `json:"values,omitempty"`
+}
+
+// Index returns an annotation that creates an index
+// for each method provided on the column.
+//
+//	field.Other("attributes", enthstore.Hstore{}).
+//		SchemaType(enthstore.Hstore{}.SchemaType()).
+//		Annotations(enthstore.Index(enthstore.GIN))
+func Index(methods ...IndexMethod) *IndexAnnotation {
+	return &IndexAnnotation{Methods: methods}
+}
+
+// ExpressionIndex returns an annotation that creates a btree index on the
+// value of each key provided, used by the Value* predicates and ordering.
+func ExpressionIndex(keys ...string) *IndexAnnotation {
+	return &IndexAnnotation{Keys: keys}
+}
+
+// ValuesIndex returns an annotation that creates a GIN index
+// on avals(col), used by the AnyValueEQ predicate.
+func ValuesIndex() *IndexAnnotation {
+	return &IndexAnnotation{Values: true}
+}
+
+// Name implements the schema.Annotation interface.
+func (IndexAnnotation) Name() string {
+	return "EntHstoreIndex"
+}
+
+// Merge implements the schema.Merger interface.
+func (a IndexAnnotation) Merge(other entschema.Annotation) entschema.Annotation {
+	var ant IndexAnnotation
+	switch other := other.(type) {
+	case IndexAnnotation:
+		ant = other
+	case *IndexAnnotation:
+		if other != nil {
+			ant = *other
+		}
+	default:
+		return a
+	}
+
+	a.Methods = append(append([]IndexMethod(nil), a.Methods...), ant.Methods...)
+	a.Keys = append(append([]string(nil), a.Keys...), ant.Keys...)
+	a.Values = a.Values || ant.Values
+	return a
+}
+
+// IndexesOption returns a schema.MigrateOption that creates the indexes
+// defined by the IndexAnnotation on the fields of the ent schema. The ent
+// schema is stored on the table provided, the indexes are added to the desired
+// state of the Atlas migrations, like the ChecksOption.
+//
+//	client.Schema.Create(ctx, enthstore.IndexesOption(user.Table, schema.User{}))
+func IndexesOption(table string, s ent.Interface) schema.MigrateOption {
+	indexes := schemaIndexes(s)
+	columns := make([]string, 0, len(indexes))
+	for column := range indexes {
+		columns = append(columns, column)
+	}
+
+	sort.Strings(columns)
+
+	return schema.WithDiffHook(func(next schema.Differ) schema.Differ {
+		return schema.DiffFunc(func(current, desired *atlas.Schema) ([]atlas.Change, error) {
+			if t, found := desired.Table(table); found {
+				for _, column := range columns {
+					addIndexes(t, column, indexes[column])
+				}
+			}
+
+			return next.Diff(current, desired)
+		})
+	})
+}
+
+// schemaIndexes returns the index annotations of the fields keyed by column.
+func schemaIndexes(s ent.Interface) map[string]IndexAnnotation {
+	indexes := make(map[string]IndexAnnotation)
+
+	fields := s.Fields()
+	for _, m := range s.Mixin() {
+		fields = append(fields, m.Fields()...)
+	}
+
+	for _, f := range fields {
+		desc := f.Descriptor()
+		column := desc.Name
+		if desc.StorageKey != "" {
+			column = desc.StorageKey
+		}
+
+		for _, ant := range desc.Annotations {
+			if ant.Name() != (IndexAnnotation{}).Name() {
+				continue
+			}
+
+			indexes[column], _ = indexes[column].Merge(ant).(IndexAnnotation)
+		}
+	}
+
+	return indexes
+}
+
+// addIndexes adds the indexes of the annotation to the table,
+// the tables without the column are not changed.
+func addIndexes(t *atlas.Table, column string, ant IndexAnnotation) {
+	c, found := t.Column(column)
+	if !found {
+		return
+	}
+
+	for _, method := range ant.Methods {
+		name := indexName(t.Name + "_" + column + "_" + strings.ToLower(string(method)))
+		if _, found := t.Index(name); found {
+			continue
+		}
+
+		t.AddIndexes(atlas.NewIndex(name).
+			AddColumns(c).
+			AddAttrs(&postgres.IndexType{T: string(method)}))
+	}
+
+	for _, key := range ant.Keys {
+		name := indexName(t.Name + "_" + column + "_" + key)
+		if _, found := t.Index(name); found {
+			continue
+		}
+
+		t.AddIndexes(atlas.NewIndex(name).AddExprs(&atlas.RawExpr{X: indexExpr(column, key)}))
+	}
+
+	name := indexName(t.Name + "_" + column + "_values")
+	if _, found := t.Index(name); ant.Values && !found {
+		t.AddIndexes(atlas.NewIndex(name).
+			AddExprs(&atlas.RawExpr{X: "avals(" + indexColumn(column) + ")"}).
+			AddAttrs(&postgres.IndexType{T: string(GIN)}))
+	}
+}
+
+// indexName returns the name of the index, the names that aren't valid
+// identifiers or are longer than maxNameLength are sanitized and end with
+// a hash of the original name, like the names of the CHECK constraints.
+func indexName(name string) string {
+	sanitized := sanitizeName(name)
+	if sanitized == name && len(name) <= maxNameLength {
+		return name
+	}
+
+	return hashedName(sanitized, name)
+}
+
+// simpleIdent matches the identifiers that Postgres doesn't quote.
+var simpleIdent = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// indexExpr returns the expression of the value of the key written like
+// Postgres prints it on the index definition, so the index is not changed
+// by the next migrations.
+func indexExpr(column string, key string) string {
+	return indexColumn(column) + " -> " + quoteKey(key) + "::text"
+}
+
+// indexColumn returns the column quoted like Postgres prints it on the index definition.
+func indexColumn(column string) string {
+	if !simpleIdent.MatchString(column) {
+		return `"` + strings.ReplaceAll(column, `"`, `""`) + `"`
+	}
+
+	return column
+}
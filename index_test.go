@@ -0,0 +1,102 @@
+package enthstore
+
+import (
+	"strings"
+	"testing"
+
+	"ariga.io/atlas/sql/postgres"
+	atlas "ariga.io/atlas/sql/schema"
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"github.com/stretchr/testify/require"
+)
+
+type indexSchema struct {
+	ent.Schema
+}
+
+func (indexSchema) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name"),
+		field.Other("attributes", Hstore{}).
+			SchemaType(Hstore{}.SchemaType()).
+			Annotations(Index(GIN), ExpressionIndex("a", "b")),
+		field.Other("settings", Hstore{}).
+			SchemaType(Hstore{}.SchemaType()).
+			StorageKey("Settings").
+			Annotations(Index(GiST)),
+	}
+}
+
+func TestSchemaIndexes(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, map[string]IndexAnnotation{
+		"attributes": {Methods: []IndexMethod{GIN}, Keys: []string{"a", "b"}},
+		"Settings":   {Methods: []IndexMethod{GiST}},
+	}, schemaIndexes(indexSchema{}))
+}
+
+func TestIndexAnnotation_Merge(t *testing.T) {
+	t.Parallel()
+
+	a := Index(GIN)
+	merged := a.Merge(ExpressionIndex("a")).(IndexAnnotation).Merge(IndexAnnotation{Keys: []string{"b"}})
+	require.Equal(t, IndexAnnotation{Methods: []IndexMethod{GIN}, Keys: []string{"a", "b"}}, merged)
+	require.Equal(t, &IndexAnnotation{Methods: []IndexMethod{GIN}}, a)
+	require.Equal(t, IndexAnnotation{Keys: []string{"a"}}, IndexAnnotation{Keys: []string{"a"}}.Merge(nil))
+	require.Equal(t, IndexAnnotation{Keys: []string{"a"}, Values: true}, ExpressionIndex("a").Merge(ValuesIndex()))
+}
+
+func TestAddIndexes(t *testing.T) {
+	t.Parallel()
+
+	attributes := hstoreColumn()
+	settings := atlas.NewColumn("Settings").SetType(&postgres.UserDefinedType{T: "hstore"})
+	table := atlas.NewTable("users").AddColumns(attributes, settings)
+
+	ant := IndexAnnotation{Methods: []IndexMethod{GIN, GiST}, Keys: []string{"a", "it's"}, Values: true}
+	addIndexes(table, "attributes", ant)
+	addIndexes(table, "attributes", ant)
+	addIndexes(table, "Settings", ExpressionIndex("a").Merge(ValuesIndex()).(IndexAnnotation))
+	addIndexes(table, "missing", ant)
+
+	require.Len(t, table.Indexes, 7)
+
+	gin, found := table.Index("users_attributes_gin")
+	require.True(t, found)
+	require.Equal(t, []atlas.Attr{&postgres.IndexType{T: "GIN"}}, gin.Attrs)
+	require.Equal(t, attributes, gin.Parts[0].C)
+
+	gist, found := table.Index("users_attributes_gist")
+	require.True(t, found)
+	require.Equal(t, []atlas.Attr{&postgres.IndexType{T: "GIST"}}, gist.Attrs)
+
+	values, found := table.Index("users_Settings_values")
+	require.True(t, found)
+	require.Equal(t, []atlas.Attr{&postgres.IndexType{T: "GIN"}}, values.Attrs)
+
+	for name, expr := range map[string]string{
+		"users_attributes_a":             `attributes -> 'a'::text`,
+		"users_attributes_it_s_740ec03a": `attributes -> 'it''s'::text`,
+		"users_Settings_a":               `"Settings" -> 'a'::text`,
+		"users_attributes_values":        `avals(attributes)`,
+		"users_Settings_values":          `avals("Settings")`,
+	} {
+		idx, found := table.Index(name)
+		require.True(t, found)
+		require.Len(t, idx.Parts, 1)
+		require.Equal(t, &atlas.RawExpr{X: expr}, idx.Parts[0].X)
+	}
+}
+
+func TestIndexName(t *testing.T) {
+	t.Parallel()
+
+	long := "users_attributes_" + strings.Repeat("k", 60)
+	require.Equal(t, "users_attributes_a", indexName("users_attributes_a"))
+	require.Equal(t, "users_attributes_it_s_740ec03a", indexName("users_attributes_it's"))
+	require.Equal(t, long[:54]+"_8babac3e", indexName(long))
+	require.Len(t, indexName(long), 63)
+	require.NotEqual(t, indexName("users_attributes_a b"), indexName("users_attributes_a_b"))
+}
@@ -1,10 +1,14 @@
 package databasetest
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"os"
 	"testing"
+
+	"github.com/crossworth/enthstore/pgxhstore"
+	"github.com/jackc/pgx/v4"
 	// pgx.
 	_ "github.com/jackc/pgx/v4/stdlib"
 	// libpq.
@@ -66,3 +70,35 @@ GRANT ALL ON SCHEMA public TO public;
 	// run the test
 	fn(db, cleanupFunc)
 }
+
+// RunWithPGX runs the test using a native pgx connection with
+// the hstore extension enabled and the hstore type registered.
+func RunWithPGX(t *testing.T, fn func(conn *pgx.Conn)) {
+	t.Helper()
+
+	RunWithDatabase(t, "pgx", func(db *sql.DB, purgeDB func()) {
+		_, err := db.Exec("CREATE EXTENSION IF NOT EXISTS hstore WITH SCHEMA public;")
+		if err != nil {
+			t.Fatalf("could not enable hstore extension, %v", err)
+		}
+
+		ctx := context.Background()
+
+		conn, err := pgx.Connect(ctx, GetTestingDatabaseAddress(t))
+		if err != nil {
+			t.Fatalf("could not connect to the local database, %v", err)
+		}
+
+		defer func() {
+			_ = conn.Close(ctx)
+		}()
+
+		err = pgxhstore.Register(ctx, conn)
+		if err != nil {
+			t.Fatalf("could not register hstore type, %v", err)
+		}
+
+		// run the test
+		fn(conn)
+	})
+}
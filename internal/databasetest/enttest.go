@@ -1,9 +1,7 @@
 package databasetest
 
 import (
-	"context"
 	"database/sql"
-	"fmt"
 	"testing"
 
 	"internal/ent/ent"
@@ -11,7 +9,7 @@ import (
 
 	"entgo.io/ent/dialect"
 	entsql "entgo.io/ent/dialect/sql"
-	"entgo.io/ent/dialect/sql/schema"
+	"github.com/crossworth/enthstore"
 )
 
 // RunWithEnt runs a test with a database and *ent.Client.
@@ -26,7 +24,7 @@ func RunWithEnt(t *testing.T, driver string, fn func(client *ent.Client)) {
 				ent.Driver(drv),
 			),
 			enttest.WithMigrateOptions(
-				EnableHstoreOption(db),
+				enthstore.EnableHstoreOption(),
 			),
 		)
 
@@ -38,18 +36,3 @@ func RunWithEnt(t *testing.T, driver string, fn func(client *ent.Client)) {
 		fn(client)
 	})
 }
-
-// EnableHstoreOption returns a schema.MigrateOption
-// that will enable the Postgres hstore extension if needed.
-func EnableHstoreOption(db *sql.DB) schema.MigrateOption {
-	return schema.WithHooks(func(next schema.Creator) schema.Creator {
-		return schema.CreateFunc(func(ctx context.Context, tables ...*schema.Table) error {
-			_, err := db.ExecContext(ctx, `CREATE EXTENSION IF NOT EXISTS hstore WITH SCHEMA public;`)
-			if err != nil {
-				return fmt.Errorf("could not enable hstore extension: %w", err)
-			}
-
-			return next.Create(ctx, tables...)
-		})
-	})
-}
@@ -0,0 +1,23 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"log"
+
+	"entgo.io/ent/entc"
+	"entgo.io/ent/entc/gen"
+	"github.com/crossworth/enthstore/hstoregen"
+)
+
+func main() {
+	err := entc.Generate("./schema", &gen.Config{
+		Features: []gen.Feature{
+			gen.FeatureModifier,
+		},
+	}, entc.Extensions(hstoregen.NewExtension()))
+	if err != nil {
+		log.Fatalf("running ent codegen: %v", err)
+	}
+}
@@ -6,6 +6,13 @@ import (
 	"github.com/crossworth/enthstore"
 )
 
+// Settings is the struct stored on the settings field.
+type Settings struct {
+	Theme    string  `hstore:"theme"`
+	Language *string `hstore:"language"`
+	Retries  int     `hstore:"retries,omitempty"`
+}
+
 // User holds the schema definition for the User entity.
 type User struct {
 	ent.Schema
@@ -18,7 +25,11 @@ func (User) Fields() []ent.Field {
 			SchemaType(enthstore.Hstore{}.SchemaType()).
 			Default(func() enthstore.Hstore {
 				return enthstore.Hstore{}
-			}),
+			}).
+			Annotations(enthstore.Index(enthstore.GIN), enthstore.ExpressionIndex("a")),
+		field.Other("settings", enthstore.Typed[Settings]{}).
+			SchemaType(enthstore.Typed[Settings]{}.SchemaType()).
+			Optional(),
 	}
 }
 
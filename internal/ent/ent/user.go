@@ -1,9 +1,10 @@
-// Code generated by entc, DO NOT EDIT.
+// Code generated by ent, DO NOT EDIT.
 
 package ent
 
 import (
 	"fmt"
+	schema "internal/ent/ent/schema"
 	"internal/ent/ent/user"
 	"strings"
 
@@ -18,6 +19,8 @@ type User struct {
 	ID int `json:"id,omitempty"`
 	// Attributes holds the value of the "attributes" field.
 	Attributes enthstore.Hstore `json:"attributes,omitempty"`
+	// Settings holds the value of the "settings" field.
+	Settings enthstore.Typed[schema.Settings] `json:"settings,omitempty"`
 }
 
 // scanValues returns the types for scanning values from sql.Rows.
@@ -27,6 +30,8 @@ func (*User) scanValues(columns []string) ([]interface{}, error) {
 		switch columns[i] {
 		case user.FieldAttributes:
 			values[i] = new(enthstore.Hstore)
+		case user.FieldSettings:
+			values[i] = new(enthstore.Typed[schema.Settings])
 		case user.FieldID:
 			values[i] = new(sql.NullInt64)
 		default:
@@ -56,6 +61,12 @@ func (u *User) assignValues(columns []string, values []interface{}) error {
 			} else if value != nil {
 				u.Attributes = *value
 			}
+		case user.FieldSettings:
+			if value, ok := values[i].(*enthstore.Typed[schema.Settings]); !ok {
+				return fmt.Errorf("unexpected type %T for field settings", values[i])
+			} else if value != nil {
+				u.Settings = *value
+			}
 		}
 	}
 	return nil
@@ -71,11 +82,11 @@ func (u *User) Update() *UserUpdateOne {
 // Unwrap unwraps the User entity that was returned from a transaction after it was closed,
 // so that all future queries will be executed through the driver which created the transaction.
 func (u *User) Unwrap() *User {
-	tx, ok := u.config.driver.(*txDriver)
+	_tx, ok := u.config.driver.(*txDriver)
 	if !ok {
 		panic("ent: User is not a transactional entity")
 	}
-	u.config.driver = tx.drv
+	u.config.driver = _tx.drv
 	return u
 }
 
@@ -83,9 +94,12 @@ func (u *User) Unwrap() *User {
 func (u *User) String() string {
 	var builder strings.Builder
 	builder.WriteString("User(")
-	builder.WriteString(fmt.Sprintf("id=%v", u.ID))
-	builder.WriteString(", attributes=")
+	builder.WriteString(fmt.Sprintf("id=%v, ", u.ID))
+	builder.WriteString("attributes=")
 	builder.WriteString(fmt.Sprintf("%v", u.Attributes))
+	builder.WriteString(", ")
+	builder.WriteString("settings=")
+	builder.WriteString(fmt.Sprintf("%v", u.Settings))
 	builder.WriteByte(')')
 	return builder.String()
 }
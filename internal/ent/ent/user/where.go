@@ -1,9 +1,10 @@
-// Code generated by entc, DO NOT EDIT.
+// Code generated by ent, DO NOT EDIT.
 
 package user
 
 import (
 	"internal/ent/ent/predicate"
+	schema "internal/ent/ent/schema"
 
 	"entgo.io/ent/dialect/sql"
 	"github.com/crossworth/enthstore"
@@ -33,12 +34,6 @@ func IDNEQ(id int) predicate.User {
 // IDIn applies the In predicate on the ID field.
 func IDIn(ids ...int) predicate.User {
 	return predicate.User(func(s *sql.Selector) {
-		// if not arguments were provided, append the FALSE constants,
-		// since we can't apply "IN ()". This will make this predicate falsy.
-		if len(ids) == 0 {
-			s.Where(sql.False())
-			return
-		}
 		v := make([]interface{}, len(ids))
 		for i := range v {
 			v[i] = ids[i]
@@ -50,12 +45,6 @@ func IDIn(ids ...int) predicate.User {
 // IDNotIn applies the NotIn predicate on the ID field.
 func IDNotIn(ids ...int) predicate.User {
 	return predicate.User(func(s *sql.Selector) {
-		// if not arguments were provided, append the FALSE constants,
-		// since we can't apply "IN ()". This will make this predicate falsy.
-		if len(ids) == 0 {
-			s.Where(sql.False())
-			return
-		}
 		v := make([]interface{}, len(ids))
 		for i := range v {
 			v[i] = ids[i]
@@ -99,6 +88,13 @@ func Attributes(v enthstore.Hstore) predicate.User {
 	})
 }
 
+// Settings applies equality check predicate on the "settings" field. It's identical to SettingsEQ.
+func Settings(v enthstore.Typed[schema.Settings]) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldSettings), v))
+	})
+}
+
 // AttributesEQ applies the EQ predicate on the "attributes" field.
 func AttributesEQ(v enthstore.Hstore) predicate.User {
 	return predicate.User(func(s *sql.Selector) {
@@ -120,12 +116,6 @@ func AttributesIn(vs ...enthstore.Hstore) predicate.User {
 		v[i] = vs[i]
 	}
 	return predicate.User(func(s *sql.Selector) {
-		// if not arguments were provided, append the FALSE constants,
-		// since we can't apply "IN ()". This will make this predicate falsy.
-		if len(v) == 0 {
-			s.Where(sql.False())
-			return
-		}
 		s.Where(sql.In(s.C(FieldAttributes), v...))
 	})
 }
@@ -137,12 +127,6 @@ func AttributesNotIn(vs ...enthstore.Hstore) predicate.User {
 		v[i] = vs[i]
 	}
 	return predicate.User(func(s *sql.Selector) {
-		// if not arguments were provided, append the FALSE constants,
-		// since we can't apply "IN ()". This will make this predicate falsy.
-		if len(v) == 0 {
-			s.Where(sql.False())
-			return
-		}
 		s.Where(sql.NotIn(s.C(FieldAttributes), v...))
 	})
 }
@@ -175,6 +159,84 @@ func AttributesLTE(v enthstore.Hstore) predicate.User {
 	})
 }
 
+// SettingsEQ applies the EQ predicate on the "settings" field.
+func SettingsEQ(v enthstore.Typed[schema.Settings]) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldSettings), v))
+	})
+}
+
+// SettingsNEQ applies the NEQ predicate on the "settings" field.
+func SettingsNEQ(v enthstore.Typed[schema.Settings]) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldSettings), v))
+	})
+}
+
+// SettingsIn applies the In predicate on the "settings" field.
+func SettingsIn(vs ...enthstore.Typed[schema.Settings]) predicate.User {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.In(s.C(FieldSettings), v...))
+	})
+}
+
+// SettingsNotIn applies the NotIn predicate on the "settings" field.
+func SettingsNotIn(vs ...enthstore.Typed[schema.Settings]) predicate.User {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.NotIn(s.C(FieldSettings), v...))
+	})
+}
+
+// SettingsGT applies the GT predicate on the "settings" field.
+func SettingsGT(v enthstore.Typed[schema.Settings]) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldSettings), v))
+	})
+}
+
+// SettingsGTE applies the GTE predicate on the "settings" field.
+func SettingsGTE(v enthstore.Typed[schema.Settings]) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldSettings), v))
+	})
+}
+
+// SettingsLT applies the LT predicate on the "settings" field.
+func SettingsLT(v enthstore.Typed[schema.Settings]) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldSettings), v))
+	})
+}
+
+// SettingsLTE applies the LTE predicate on the "settings" field.
+func SettingsLTE(v enthstore.Typed[schema.Settings]) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldSettings), v))
+	})
+}
+
+// SettingsIsNil applies the IsNil predicate on the "settings" field.
+func SettingsIsNil() predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldSettings)))
+	})
+}
+
+// SettingsNotNil applies the NotNil predicate on the "settings" field.
+func SettingsNotNil() predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldSettings)))
+	})
+}
+
 // And groups predicates with the AND operator between them.
 func And(predicates ...predicate.User) predicate.User {
 	return predicate.User(func(s *sql.Selector) {
@@ -206,3 +268,535 @@ func Not(p predicate.User) predicate.User {
 		p(s.Not())
 	})
 }
+
+// AttributesHasKey applies the enthstore.HasKey predicate on the "attributes" field.
+func AttributesHasKey(key string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.HasKey(s.C(FieldAttributes), key))
+	})
+}
+
+// AttributesHasAllKeys applies the enthstore.HasAllKeys predicate on the "attributes" field.
+func AttributesHasAllKeys(keys ...string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.HasAllKeys(s.C(FieldAttributes), keys...))
+	})
+}
+
+// AttributesHasAnyKeys applies the enthstore.HasAnyKeys predicate on the "attributes" field.
+func AttributesHasAnyKeys(keys ...string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.HasAnyKeys(s.C(FieldAttributes), keys...))
+	})
+}
+
+// AttributesNotHasKey applies the enthstore.NotHasKey predicate on the "attributes" field.
+func AttributesNotHasKey(key string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.NotHasKey(s.C(FieldAttributes), key))
+	})
+}
+
+// AttributesContains applies the enthstore.Contains predicate on the "attributes" field.
+func AttributesContains(h enthstore.Hstore) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.Contains(s.C(FieldAttributes), h))
+	})
+}
+
+// AttributesNotContains applies the enthstore.NotContains predicate on the "attributes" field.
+func AttributesNotContains(h enthstore.Hstore) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.NotContains(s.C(FieldAttributes), h))
+	})
+}
+
+// AttributesContainedBy applies the enthstore.ContainedBy predicate on the "attributes" field.
+func AttributesContainedBy(h enthstore.Hstore) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.ContainedBy(s.C(FieldAttributes), h))
+	})
+}
+
+// AttributesNotContainedBy applies the enthstore.NotContainedBy predicate on the "attributes" field.
+func AttributesNotContainedBy(h enthstore.Hstore) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.NotContainedBy(s.C(FieldAttributes), h))
+	})
+}
+
+// AttributesValueIsNull applies the enthstore.ValueIsNull predicate on the "attributes" field.
+func AttributesValueIsNull(key string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.ValueIsNull(s.C(FieldAttributes), key))
+	})
+}
+
+// AttributesValueEQ applies the enthstore.ValueEQ predicate on the "attributes" field.
+func AttributesValueEQ(key, val string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.ValueEQ(s.C(FieldAttributes), key, val))
+	})
+}
+
+// AttributesValueNEQ applies the enthstore.ValueNEQ predicate on the "attributes" field.
+func AttributesValueNEQ(key, val string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.ValueNEQ(s.C(FieldAttributes), key, val))
+	})
+}
+
+// AttributesValueGT applies the enthstore.ValueGT predicate on the "attributes" field.
+func AttributesValueGT(key, val string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.ValueGT(s.C(FieldAttributes), key, val))
+	})
+}
+
+// AttributesValueGTE applies the enthstore.ValueGTE predicate on the "attributes" field.
+func AttributesValueGTE(key, val string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.ValueGTE(s.C(FieldAttributes), key, val))
+	})
+}
+
+// AttributesValueLT applies the enthstore.ValueLT predicate on the "attributes" field.
+func AttributesValueLT(key, val string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.ValueLT(s.C(FieldAttributes), key, val))
+	})
+}
+
+// AttributesValueLTE applies the enthstore.ValueLTE predicate on the "attributes" field.
+func AttributesValueLTE(key, val string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.ValueLTE(s.C(FieldAttributes), key, val))
+	})
+}
+
+// AttributesValueIn applies the enthstore.ValueIn predicate on the "attributes" field.
+func AttributesValueIn(key string, vals ...string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.ValueIn(s.C(FieldAttributes), key, vals...))
+	})
+}
+
+// AttributesValueNotIn applies the enthstore.ValueNotIn predicate on the "attributes" field.
+func AttributesValueNotIn(key string, vals ...string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.ValueNotIn(s.C(FieldAttributes), key, vals...))
+	})
+}
+
+// AttributesValueContains applies the enthstore.ValueContains predicate on the "attributes" field.
+func AttributesValueContains(key, val string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.ValueContains(s.C(FieldAttributes), key, val))
+	})
+}
+
+// AttributesValueHasPrefix applies the enthstore.ValueHasPrefix predicate on the "attributes" field.
+func AttributesValueHasPrefix(key, val string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.ValueHasPrefix(s.C(FieldAttributes), key, val))
+	})
+}
+
+// AttributesValueHasSuffix applies the enthstore.ValueHasSuffix predicate on the "attributes" field.
+func AttributesValueHasSuffix(key, val string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.ValueHasSuffix(s.C(FieldAttributes), key, val))
+	})
+}
+
+// AttributesValueEqualFold applies the enthstore.ValueEqualFold predicate on the "attributes" field.
+func AttributesValueEqualFold(key, val string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.ValueEqualFold(s.C(FieldAttributes), key, val))
+	})
+}
+
+// AttributesValueContainsFold applies the enthstore.ValueContainsFold predicate on the "attributes" field.
+func AttributesValueContainsFold(key, val string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.ValueContainsFold(s.C(FieldAttributes), key, val))
+	})
+}
+
+// AttributesValueLike applies the enthstore.ValueLike predicate on the "attributes" field.
+func AttributesValueLike(key, pattern string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.ValueLike(s.C(FieldAttributes), key, pattern))
+	})
+}
+
+// AttributesValueNotLike applies the enthstore.ValueNotLike predicate on the "attributes" field.
+func AttributesValueNotLike(key, pattern string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.ValueNotLike(s.C(FieldAttributes), key, pattern))
+	})
+}
+
+// AttributesValueRegex applies the enthstore.ValueRegex predicate on the "attributes" field.
+func AttributesValueRegex(key, pattern string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.ValueRegex(s.C(FieldAttributes), key, pattern))
+	})
+}
+
+// AttributesValueRegexFold applies the enthstore.ValueRegexFold predicate on the "attributes" field.
+func AttributesValueRegexFold(key, pattern string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.ValueRegexFold(s.C(FieldAttributes), key, pattern))
+	})
+}
+
+// AttributesValueSimilarTo applies the enthstore.ValueSimilarTo predicate on the "attributes" field.
+func AttributesValueSimilarTo(key, pattern string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.ValueSimilarTo(s.C(FieldAttributes), key, pattern))
+	})
+}
+
+// AttributesAnyValueEQ applies the enthstore.AnyValueEQ predicate on the "attributes" field.
+func AttributesAnyValueEQ(val string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.AnyValueEQ(s.C(FieldAttributes), val))
+	})
+}
+
+// AttributesAnyValueContains applies the enthstore.AnyValueContains predicate on the "attributes" field.
+func AttributesAnyValueContains(val string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.AnyValueContains(s.C(FieldAttributes), val))
+	})
+}
+
+// AttributesKeyMatches applies the enthstore.KeyMatches predicate on the "attributes" field.
+func AttributesKeyMatches(pattern string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.KeyMatches(s.C(FieldAttributes), pattern))
+	})
+}
+
+// AttributesIsEmpty applies the enthstore.IsEmpty predicate on the "attributes" field.
+func AttributesIsEmpty() predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.IsEmpty(s.C(FieldAttributes)))
+	})
+}
+
+// AttributesIsNotEmpty applies the enthstore.IsNotEmpty predicate on the "attributes" field.
+func AttributesIsNotEmpty() predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.IsNotEmpty(s.C(FieldAttributes)))
+	})
+}
+
+// AttributesKeyCountEQ applies the enthstore.KeyCountEQ predicate on the "attributes" field.
+func AttributesKeyCountEQ(n int) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.KeyCountEQ(s.C(FieldAttributes), n))
+	})
+}
+
+// AttributesKeyCountNEQ applies the enthstore.KeyCountNEQ predicate on the "attributes" field.
+func AttributesKeyCountNEQ(n int) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.KeyCountNEQ(s.C(FieldAttributes), n))
+	})
+}
+
+// AttributesKeyCountGT applies the enthstore.KeyCountGT predicate on the "attributes" field.
+func AttributesKeyCountGT(n int) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.KeyCountGT(s.C(FieldAttributes), n))
+	})
+}
+
+// AttributesKeyCountGTE applies the enthstore.KeyCountGTE predicate on the "attributes" field.
+func AttributesKeyCountGTE(n int) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.KeyCountGTE(s.C(FieldAttributes), n))
+	})
+}
+
+// AttributesKeyCountLT applies the enthstore.KeyCountLT predicate on the "attributes" field.
+func AttributesKeyCountLT(n int) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.KeyCountLT(s.C(FieldAttributes), n))
+	})
+}
+
+// AttributesKeyCountLTE applies the enthstore.KeyCountLTE predicate on the "attributes" field.
+func AttributesKeyCountLTE(n int) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.KeyCountLTE(s.C(FieldAttributes), n))
+	})
+}
+
+// SettingsHasKey applies the enthstore.HasKey predicate on the "settings" field.
+func SettingsHasKey(key string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.HasKey(s.C(FieldSettings), key))
+	})
+}
+
+// SettingsHasAllKeys applies the enthstore.HasAllKeys predicate on the "settings" field.
+func SettingsHasAllKeys(keys ...string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.HasAllKeys(s.C(FieldSettings), keys...))
+	})
+}
+
+// SettingsHasAnyKeys applies the enthstore.HasAnyKeys predicate on the "settings" field.
+func SettingsHasAnyKeys(keys ...string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.HasAnyKeys(s.C(FieldSettings), keys...))
+	})
+}
+
+// SettingsNotHasKey applies the enthstore.NotHasKey predicate on the "settings" field.
+func SettingsNotHasKey(key string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.NotHasKey(s.C(FieldSettings), key))
+	})
+}
+
+// SettingsContains applies the enthstore.Contains predicate on the "settings" field.
+func SettingsContains(h enthstore.Hstore) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.Contains(s.C(FieldSettings), h))
+	})
+}
+
+// SettingsNotContains applies the enthstore.NotContains predicate on the "settings" field.
+func SettingsNotContains(h enthstore.Hstore) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.NotContains(s.C(FieldSettings), h))
+	})
+}
+
+// SettingsContainedBy applies the enthstore.ContainedBy predicate on the "settings" field.
+func SettingsContainedBy(h enthstore.Hstore) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.ContainedBy(s.C(FieldSettings), h))
+	})
+}
+
+// SettingsNotContainedBy applies the enthstore.NotContainedBy predicate on the "settings" field.
+func SettingsNotContainedBy(h enthstore.Hstore) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.NotContainedBy(s.C(FieldSettings), h))
+	})
+}
+
+// SettingsValueIsNull applies the enthstore.ValueIsNull predicate on the "settings" field.
+func SettingsValueIsNull(key string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.ValueIsNull(s.C(FieldSettings), key))
+	})
+}
+
+// SettingsValueEQ applies the enthstore.ValueEQ predicate on the "settings" field.
+func SettingsValueEQ(key, val string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.ValueEQ(s.C(FieldSettings), key, val))
+	})
+}
+
+// SettingsValueNEQ applies the enthstore.ValueNEQ predicate on the "settings" field.
+func SettingsValueNEQ(key, val string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.ValueNEQ(s.C(FieldSettings), key, val))
+	})
+}
+
+// SettingsValueGT applies the enthstore.ValueGT predicate on the "settings" field.
+func SettingsValueGT(key, val string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.ValueGT(s.C(FieldSettings), key, val))
+	})
+}
+
+// SettingsValueGTE applies the enthstore.ValueGTE predicate on the "settings" field.
+func SettingsValueGTE(key, val string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.ValueGTE(s.C(FieldSettings), key, val))
+	})
+}
+
+// SettingsValueLT applies the enthstore.ValueLT predicate on the "settings" field.
+func SettingsValueLT(key, val string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.ValueLT(s.C(FieldSettings), key, val))
+	})
+}
+
+// SettingsValueLTE applies the enthstore.ValueLTE predicate on the "settings" field.
+func SettingsValueLTE(key, val string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.ValueLTE(s.C(FieldSettings), key, val))
+	})
+}
+
+// SettingsValueIn applies the enthstore.ValueIn predicate on the "settings" field.
+func SettingsValueIn(key string, vals ...string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.ValueIn(s.C(FieldSettings), key, vals...))
+	})
+}
+
+// SettingsValueNotIn applies the enthstore.ValueNotIn predicate on the "settings" field.
+func SettingsValueNotIn(key string, vals ...string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.ValueNotIn(s.C(FieldSettings), key, vals...))
+	})
+}
+
+// SettingsValueContains applies the enthstore.ValueContains predicate on the "settings" field.
+func SettingsValueContains(key, val string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.ValueContains(s.C(FieldSettings), key, val))
+	})
+}
+
+// SettingsValueHasPrefix applies the enthstore.ValueHasPrefix predicate on the "settings" field.
+func SettingsValueHasPrefix(key, val string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.ValueHasPrefix(s.C(FieldSettings), key, val))
+	})
+}
+
+// SettingsValueHasSuffix applies the enthstore.ValueHasSuffix predicate on the "settings" field.
+func SettingsValueHasSuffix(key, val string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.ValueHasSuffix(s.C(FieldSettings), key, val))
+	})
+}
+
+// SettingsValueEqualFold applies the enthstore.ValueEqualFold predicate on the "settings" field.
+func SettingsValueEqualFold(key, val string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.ValueEqualFold(s.C(FieldSettings), key, val))
+	})
+}
+
+// SettingsValueContainsFold applies the enthstore.ValueContainsFold predicate on the "settings" field.
+func SettingsValueContainsFold(key, val string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.ValueContainsFold(s.C(FieldSettings), key, val))
+	})
+}
+
+// SettingsValueLike applies the enthstore.ValueLike predicate on the "settings" field.
+func SettingsValueLike(key, pattern string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.ValueLike(s.C(FieldSettings), key, pattern))
+	})
+}
+
+// SettingsValueNotLike applies the enthstore.ValueNotLike predicate on the "settings" field.
+func SettingsValueNotLike(key, pattern string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.ValueNotLike(s.C(FieldSettings), key, pattern))
+	})
+}
+
+// SettingsValueRegex applies the enthstore.ValueRegex predicate on the "settings" field.
+func SettingsValueRegex(key, pattern string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.ValueRegex(s.C(FieldSettings), key, pattern))
+	})
+}
+
+// SettingsValueRegexFold applies the enthstore.ValueRegexFold predicate on the "settings" field.
+func SettingsValueRegexFold(key, pattern string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.ValueRegexFold(s.C(FieldSettings), key, pattern))
+	})
+}
+
+// SettingsValueSimilarTo applies the enthstore.ValueSimilarTo predicate on the "settings" field.
+func SettingsValueSimilarTo(key, pattern string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.ValueSimilarTo(s.C(FieldSettings), key, pattern))
+	})
+}
+
+// SettingsAnyValueEQ applies the enthstore.AnyValueEQ predicate on the "settings" field.
+func SettingsAnyValueEQ(val string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.AnyValueEQ(s.C(FieldSettings), val))
+	})
+}
+
+// SettingsAnyValueContains applies the enthstore.AnyValueContains predicate on the "settings" field.
+func SettingsAnyValueContains(val string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.AnyValueContains(s.C(FieldSettings), val))
+	})
+}
+
+// SettingsKeyMatches applies the enthstore.KeyMatches predicate on the "settings" field.
+func SettingsKeyMatches(pattern string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.KeyMatches(s.C(FieldSettings), pattern))
+	})
+}
+
+// SettingsIsEmpty applies the enthstore.IsEmpty predicate on the "settings" field.
+func SettingsIsEmpty() predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.IsEmpty(s.C(FieldSettings)))
+	})
+}
+
+// SettingsIsNotEmpty applies the enthstore.IsNotEmpty predicate on the "settings" field.
+func SettingsIsNotEmpty() predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.IsNotEmpty(s.C(FieldSettings)))
+	})
+}
+
+// SettingsKeyCountEQ applies the enthstore.KeyCountEQ predicate on the "settings" field.
+func SettingsKeyCountEQ(n int) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.KeyCountEQ(s.C(FieldSettings), n))
+	})
+}
+
+// SettingsKeyCountNEQ applies the enthstore.KeyCountNEQ predicate on the "settings" field.
+func SettingsKeyCountNEQ(n int) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.KeyCountNEQ(s.C(FieldSettings), n))
+	})
+}
+
+// SettingsKeyCountGT applies the enthstore.KeyCountGT predicate on the "settings" field.
+func SettingsKeyCountGT(n int) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.KeyCountGT(s.C(FieldSettings), n))
+	})
+}
+
+// SettingsKeyCountGTE applies the enthstore.KeyCountGTE predicate on the "settings" field.
+func SettingsKeyCountGTE(n int) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.KeyCountGTE(s.C(FieldSettings), n))
+	})
+}
+
+// SettingsKeyCountLT applies the enthstore.KeyCountLT predicate on the "settings" field.
+func SettingsKeyCountLT(n int) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.KeyCountLT(s.C(FieldSettings), n))
+	})
+}
+
+// SettingsKeyCountLTE applies the enthstore.KeyCountLTE predicate on the "settings" field.
+func SettingsKeyCountLTE(n int) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(enthstore.KeyCountLTE(s.C(FieldSettings), n))
+	})
+}
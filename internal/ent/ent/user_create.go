@@ -1,4 +1,4 @@
-// Code generated by entc, DO NOT EDIT.
+// Code generated by ent, DO NOT EDIT.
 
 package ent
 
@@ -6,6 +6,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	schema "internal/ent/ent/schema"
 	"internal/ent/ent/user"
 
 	"entgo.io/ent/dialect/sql/sqlgraph"
@@ -26,6 +27,20 @@ func (uc *UserCreate) SetAttributes(e enthstore.Hstore) *UserCreate {
 	return uc
 }
 
+// SetSettings sets the "settings" field.
+func (uc *UserCreate) SetSettings(e enthstore.Typed[schema.Settings]) *UserCreate {
+	uc.mutation.SetSettings(e)
+	return uc
+}
+
+// SetNillableSettings sets the "settings" field if the given value is not nil.
+func (uc *UserCreate) SetNillableSettings(e *enthstore.Typed[schema.Settings]) *UserCreate {
+	if e != nil {
+		uc.SetSettings(*e)
+	}
+	return uc
+}
+
 // Mutation returns the UserMutation object of the builder.
 func (uc *UserCreate) Mutation() *UserMutation {
 	return uc.mutation
@@ -66,9 +81,15 @@ func (uc *UserCreate) Save(ctx context.Context) (*User, error) {
 			}
 			mut = uc.hooks[i](mut)
 		}
-		if _, err := mut.Mutate(ctx, uc.mutation); err != nil {
+		v, err := mut.Mutate(ctx, uc.mutation)
+		if err != nil {
 			return nil, err
 		}
+		nv, ok := v.(*User)
+		if !ok {
+			return nil, fmt.Errorf("unexpected node type %T returned from UserMutation", v)
+		}
+		node = nv
 	}
 	return node, err
 }
@@ -115,7 +136,7 @@ func (uc *UserCreate) sqlSave(ctx context.Context) (*User, error) {
 	_node, _spec := uc.createSpec()
 	if err := sqlgraph.CreateNode(ctx, uc.driver, _spec); err != nil {
 		if sqlgraph.IsConstraintError(err) {
-			err = &ConstraintError{err.Error(), err}
+			err = &ConstraintError{msg: err.Error(), wrap: err}
 		}
 		return nil, err
 	}
@@ -143,6 +164,14 @@ func (uc *UserCreate) createSpec() (*User, *sqlgraph.CreateSpec) {
 		})
 		_node.Attributes = value
 	}
+	if value, ok := uc.mutation.Settings(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeOther,
+			Value:  value,
+			Column: user.FieldSettings,
+		})
+		_node.Settings = value
+	}
 	return _node, _spec
 }
 
@@ -179,7 +208,7 @@ func (ucb *UserCreateBulk) Save(ctx context.Context) ([]*User, error) {
 					// Invoke the actual operation on the latest mutation in the chain.
 					if err = sqlgraph.BatchCreate(ctx, ucb.driver, spec); err != nil {
 						if sqlgraph.IsConstraintError(err) {
-							err = &ConstraintError{err.Error(), err}
+							err = &ConstraintError{msg: err.Error(), wrap: err}
 						}
 					}
 				}
@@ -187,11 +216,11 @@ func (ucb *UserCreateBulk) Save(ctx context.Context) ([]*User, error) {
 					return nil, err
 				}
 				mutation.id = &nodes[i].ID
-				mutation.done = true
 				if specs[i].ID.Value != nil {
 					id := specs[i].ID.Value.(int64)
 					nodes[i].ID = int(id)
 				}
+				mutation.done = true
 				return nodes[i], nil
 			})
 			for i := len(builder.hooks) - 1; i >= 0; i-- {
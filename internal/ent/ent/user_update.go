@@ -1,4 +1,4 @@
-// Code generated by entc, DO NOT EDIT.
+// Code generated by ent, DO NOT EDIT.
 
 package ent
 
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"internal/ent/ent/predicate"
+	schema "internal/ent/ent/schema"
 	"internal/ent/ent/user"
 
 	"entgo.io/ent/dialect/sql"
@@ -18,8 +19,9 @@ import (
 // UserUpdate is the builder for updating User entities.
 type UserUpdate struct {
 	config
-	hooks    []Hook
-	mutation *UserMutation
+	hooks     []Hook
+	mutation  *UserMutation
+	modifiers []func(*sql.UpdateBuilder)
 }
 
 // Where appends a list predicates to the UserUpdate builder.
@@ -34,6 +36,26 @@ func (uu *UserUpdate) SetAttributes(e enthstore.Hstore) *UserUpdate {
 	return uu
 }
 
+// SetSettings sets the "settings" field.
+func (uu *UserUpdate) SetSettings(e enthstore.Typed[schema.Settings]) *UserUpdate {
+	uu.mutation.SetSettings(e)
+	return uu
+}
+
+// SetNillableSettings sets the "settings" field if the given value is not nil.
+func (uu *UserUpdate) SetNillableSettings(e *enthstore.Typed[schema.Settings]) *UserUpdate {
+	if e != nil {
+		uu.SetSettings(*e)
+	}
+	return uu
+}
+
+// ClearSettings clears the value of the "settings" field.
+func (uu *UserUpdate) ClearSettings() *UserUpdate {
+	uu.mutation.ClearSettings()
+	return uu
+}
+
 // Mutation returns the UserMutation object of the builder.
 func (uu *UserUpdate) Mutation() *UserMutation {
 	return uu.mutation
@@ -93,6 +115,12 @@ func (uu *UserUpdate) ExecX(ctx context.Context) {
 	}
 }
 
+// Modify adds a statement modifier for attaching custom logic to the UPDATE statement.
+func (uu *UserUpdate) Modify(modifiers ...func(u *sql.UpdateBuilder)) *UserUpdate {
+	uu.modifiers = append(uu.modifiers, modifiers...)
+	return uu
+}
+
 func (uu *UserUpdate) sqlSave(ctx context.Context) (n int, err error) {
 	_spec := &sqlgraph.UpdateSpec{
 		Node: &sqlgraph.NodeSpec{
@@ -118,11 +146,25 @@ func (uu *UserUpdate) sqlSave(ctx context.Context) (n int, err error) {
 			Column: user.FieldAttributes,
 		})
 	}
+	if value, ok := uu.mutation.Settings(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeOther,
+			Value:  value,
+			Column: user.FieldSettings,
+		})
+	}
+	if uu.mutation.SettingsCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeOther,
+			Column: user.FieldSettings,
+		})
+	}
+	_spec.Modifiers = uu.modifiers
 	if n, err = sqlgraph.UpdateNodes(ctx, uu.driver, _spec); err != nil {
 		if _, ok := err.(*sqlgraph.NotFoundError); ok {
 			err = &NotFoundError{user.Label}
 		} else if sqlgraph.IsConstraintError(err) {
-			err = &ConstraintError{err.Error(), err}
+			err = &ConstraintError{msg: err.Error(), wrap: err}
 		}
 		return 0, err
 	}
@@ -132,9 +174,10 @@ func (uu *UserUpdate) sqlSave(ctx context.Context) (n int, err error) {
 // UserUpdateOne is the builder for updating a single User entity.
 type UserUpdateOne struct {
 	config
-	fields   []string
-	hooks    []Hook
-	mutation *UserMutation
+	fields    []string
+	hooks     []Hook
+	mutation  *UserMutation
+	modifiers []func(*sql.UpdateBuilder)
 }
 
 // SetAttributes sets the "attributes" field.
@@ -143,6 +186,26 @@ func (uuo *UserUpdateOne) SetAttributes(e enthstore.Hstore) *UserUpdateOne {
 	return uuo
 }
 
+// SetSettings sets the "settings" field.
+func (uuo *UserUpdateOne) SetSettings(e enthstore.Typed[schema.Settings]) *UserUpdateOne {
+	uuo.mutation.SetSettings(e)
+	return uuo
+}
+
+// SetNillableSettings sets the "settings" field if the given value is not nil.
+func (uuo *UserUpdateOne) SetNillableSettings(e *enthstore.Typed[schema.Settings]) *UserUpdateOne {
+	if e != nil {
+		uuo.SetSettings(*e)
+	}
+	return uuo
+}
+
+// ClearSettings clears the value of the "settings" field.
+func (uuo *UserUpdateOne) ClearSettings() *UserUpdateOne {
+	uuo.mutation.ClearSettings()
+	return uuo
+}
+
 // Mutation returns the UserMutation object of the builder.
 func (uuo *UserUpdateOne) Mutation() *UserMutation {
 	return uuo.mutation
@@ -180,9 +243,15 @@ func (uuo *UserUpdateOne) Save(ctx context.Context) (*User, error) {
 			}
 			mut = uuo.hooks[i](mut)
 		}
-		if _, err := mut.Mutate(ctx, uuo.mutation); err != nil {
+		v, err := mut.Mutate(ctx, uuo.mutation)
+		if err != nil {
 			return nil, err
 		}
+		nv, ok := v.(*User)
+		if !ok {
+			return nil, fmt.Errorf("unexpected node type %T returned from UserMutation", v)
+		}
+		node = nv
 	}
 	return node, err
 }
@@ -209,6 +278,12 @@ func (uuo *UserUpdateOne) ExecX(ctx context.Context) {
 	}
 }
 
+// Modify adds a statement modifier for attaching custom logic to the UPDATE statement.
+func (uuo *UserUpdateOne) Modify(modifiers ...func(u *sql.UpdateBuilder)) *UserUpdateOne {
+	uuo.modifiers = append(uuo.modifiers, modifiers...)
+	return uuo
+}
+
 func (uuo *UserUpdateOne) sqlSave(ctx context.Context) (_node *User, err error) {
 	_spec := &sqlgraph.UpdateSpec{
 		Node: &sqlgraph.NodeSpec{
@@ -251,6 +326,20 @@ func (uuo *UserUpdateOne) sqlSave(ctx context.Context) (_node *User, err error)
 			Column: user.FieldAttributes,
 		})
 	}
+	if value, ok := uuo.mutation.Settings(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeOther,
+			Value:  value,
+			Column: user.FieldSettings,
+		})
+	}
+	if uuo.mutation.SettingsCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeOther,
+			Column: user.FieldSettings,
+		})
+	}
+	_spec.Modifiers = uuo.modifiers
 	_node = &User{config: uuo.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues
@@ -258,7 +347,7 @@ func (uuo *UserUpdateOne) sqlSave(ctx context.Context) (_node *User, err error)
 		if _, ok := err.(*sqlgraph.NotFoundError); ok {
 			err = &NotFoundError{user.Label}
 		} else if sqlgraph.IsConstraintError(err) {
-			err = &ConstraintError{err.Error(), err}
+			err = &ConstraintError{msg: err.Error(), wrap: err}
 		}
 		return nil, err
 	}
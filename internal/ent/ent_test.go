@@ -2,15 +2,26 @@ package ent
 
 import (
 	"context"
+	stdsql "database/sql"
+	"strings"
 	"testing"
+	"time"
 
 	"internal/databasetest"
 	"internal/ent/ent"
+	"internal/ent/ent/migrate"
+	"internal/ent/ent/schema"
 	"internal/ent/ent/user"
 
+	atlasmigrate "ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/sqltool"
+	"entgo.io/ent/dialect"
 	"entgo.io/ent/dialect/sql"
+	entschema "entgo.io/ent/dialect/sql/schema"
 	"github.com/crossworth/enthstore"
 	"github.com/stretchr/testify/require"
+	// sqlite.
+	_ "github.com/mattn/go-sqlite3"
 )
 
 func TestIntegrationHstorePGX(t *testing.T) {
@@ -502,3 +513,809 @@ func TestIntegrationHstorePQ(t *testing.T) {
 		})
 	})
 }
+
+func TestIntegrationHstoreUpdate(t *testing.T) {
+	for _, driver := range []string{"pgx", "postgres"} {
+		driver := driver
+		t.Run(driver, func(t *testing.T) {
+			databasetest.RunWithEnt(t, driver, func(client *ent.Client) {
+				ctx := context.Background()
+
+				t.Run("SetKey", func(t *testing.T) {
+					defer client.User.Delete().ExecX(ctx)
+					u := client.User.Create().SetAttributes(enthstore.FromMap(map[string]string{
+						"a": "b",
+						"c": "d",
+					})).SaveX(ctx)
+					other := client.User.Create().SaveX(ctx)
+
+					client.User.UpdateOne(u).Modify(enthstore.SetKey(user.FieldAttributes, "a", "1")).ExecX(ctx)
+					client.User.UpdateOne(u).Modify(enthstore.SetKey(user.FieldAttributes, "e", "f")).ExecX(ctx)
+
+					u = client.User.GetX(ctx, u.ID)
+					require.True(t, enthstore.FromMap(map[string]string{
+						"a": "1",
+						"c": "d",
+						"e": "f",
+					}).Equals(u.Attributes))
+
+					other = client.User.GetX(ctx, other.ID)
+					require.Len(t, other.Attributes, 0)
+				})
+
+				t.Run("ApplyPatch", func(t *testing.T) {
+					defer client.User.Delete().ExecX(ctx)
+					old := enthstore.Hstore{
+						"a": enthstore.FromMap(map[string]string{"a": "b"})["a"],
+						"c": nil,
+						"d": nil,
+					}
+					u := client.User.Create().SetAttributes(old).SaveX(ctx)
+
+					updated := enthstore.FromMap(map[string]string{"a": "1", "c": "2", "e": "f"})
+					patch := enthstore.Diff(old, updated)
+					client.User.UpdateOne(u).Modify(enthstore.ApplyPatch(user.FieldAttributes, patch)).ExecX(ctx)
+
+					u = client.User.GetX(ctx, u.ID)
+					require.True(t, updated.Equals(u.Attributes))
+
+					client.User.UpdateOne(u).Modify(enthstore.ApplyPatch(user.FieldAttributes, patch.Invert())).ExecX(ctx)
+
+					u = client.User.GetX(ctx, u.ID)
+					require.True(t, old.Equals(u.Attributes))
+				})
+
+				t.Run("Merge", func(t *testing.T) {
+					defer client.User.Delete().ExecX(ctx)
+					client.User.Create().SetAttributes(enthstore.FromMap(map[string]string{
+						"a": "b",
+						"c": "d",
+					})).SaveX(ctx)
+					client.User.Create().SaveX(ctx)
+
+					merge := enthstore.FromMap(map[string]string{"a": "1", "e": "f"})
+					merge.Set("n", nil)
+
+					n := client.User.Update().Where(func(selector *sql.Selector) {
+						selector.Where(enthstore.HasKey(user.FieldAttributes, "c"))
+					}).Modify(enthstore.Merge(user.FieldAttributes, merge)).SaveX(ctx)
+					require.Equal(t, 1, n)
+
+					u := client.User.Query().Where(func(selector *sql.Selector) {
+						selector.Where(enthstore.HasKey(user.FieldAttributes, "c"))
+					}).OnlyX(ctx)
+
+					want := enthstore.FromMap(map[string]string{"a": "1", "c": "d", "e": "f"})
+					want.Set("n", nil)
+					require.True(t, want.Equals(u.Attributes))
+				})
+
+				t.Run("DeleteKeys", func(t *testing.T) {
+					defer client.User.Delete().ExecX(ctx)
+					u := client.User.Create().SetAttributes(enthstore.FromMap(map[string]string{
+						"a": "b",
+						"c": "d",
+						"e": "f",
+					})).SaveX(ctx)
+
+					client.User.UpdateOne(u).Modify(enthstore.DeleteKeys(user.FieldAttributes, "a", "e", "g")).ExecX(ctx)
+
+					u = client.User.GetX(ctx, u.ID)
+					require.True(t, enthstore.FromMap(map[string]string{"c": "d"}).Equals(u.Attributes))
+				})
+
+				t.Run("DeletePairs", func(t *testing.T) {
+					defer client.User.Delete().ExecX(ctx)
+					u := client.User.Create().SetAttributes(enthstore.FromMap(map[string]string{
+						"a": "b",
+						"c": "d",
+					})).SaveX(ctx)
+
+					client.User.UpdateOne(u).Modify(enthstore.DeletePairs(user.FieldAttributes, enthstore.FromMap(map[string]string{
+						"a": "b",
+						"c": "x",
+					}))).ExecX(ctx)
+
+					u = client.User.GetX(ctx, u.ID)
+					require.True(t, enthstore.FromMap(map[string]string{"c": "d"}).Equals(u.Attributes))
+				})
+			})
+		})
+	}
+}
+
+func TestIntegrationHstoreGeneratedPredicates(t *testing.T) {
+	for _, driver := range []string{"pgx", "postgres"} {
+		driver := driver
+		t.Run(driver, func(t *testing.T) {
+			databasetest.RunWithEnt(t, driver, func(client *ent.Client) {
+				ctx := context.Background()
+				defer client.User.Delete().ExecX(ctx)
+
+				client.User.Create().SaveX(ctx)
+				client.User.Create().SetAttributes(enthstore.FromMap(map[string]string{
+					"a": "b",
+					"c": "d",
+				})).SaveX(ctx)
+				client.User.Create().SetAttributes(enthstore.Hstore{
+					"a": nil,
+				}).SaveX(ctx)
+
+				require.Equal(t, 2, client.User.Query().Where(user.AttributesHasKey("a")).CountX(ctx))
+				require.Equal(t, 1, client.User.Query().Where(user.AttributesHasAllKeys("a", "c")).CountX(ctx))
+				require.Equal(t, 1, client.User.Query().Where(user.AttributesValueIsNull("a")).CountX(ctx))
+				require.Equal(t, 1, client.User.Query().Where(user.AttributesValueEQ("a", "b")).CountX(ctx))
+				require.Equal(t, 1, client.User.Query().Where(user.AttributesValueHasPrefix("c", "d")).CountX(ctx))
+				require.Equal(t, 2, client.User.Query().Where(
+					user.Or(
+						user.AttributesValueEQ("c", "d"),
+						user.AttributesValueIsNull("a"),
+					),
+				).CountX(ctx))
+			})
+		})
+	}
+}
+
+func TestIntegrationHstoreTyped(t *testing.T) {
+	for _, driver := range []string{"pgx", "postgres"} {
+		driver := driver
+		t.Run(driver, func(t *testing.T) {
+			databasetest.RunWithEnt(t, driver, func(client *ent.Client) {
+				ctx := context.Background()
+				defer client.User.Delete().ExecX(ctx)
+
+				language := "pt-BR"
+				u := client.User.Create().SetSettings(enthstore.NewTyped(schema.Settings{
+					Theme:    "dark",
+					Language: &language,
+				})).SaveX(ctx)
+
+				u = client.User.GetX(ctx, u.ID)
+				require.Equal(t, "dark", u.Settings.Data.Theme)
+				require.Equal(t, &language, u.Settings.Data.Language)
+				require.Zero(t, u.Settings.Data.Retries)
+
+				require.Equal(t, 1, client.User.Query().Where(user.SettingsValueEQ("theme", "dark")).CountX(ctx))
+				require.Equal(t, 0, client.User.Query().Where(user.SettingsHasKey("retries")).CountX(ctx))
+
+				empty := client.User.Create().SaveX(ctx)
+				empty = client.User.GetX(ctx, empty.ID)
+				require.Equal(t, schema.Settings{}, empty.Settings.Data)
+			})
+		})
+	}
+}
+
+func TestIntegrationHstoreKeySetPredicates(t *testing.T) {
+	for _, driver := range []string{"pgx", "postgres"} {
+		driver := driver
+		t.Run(driver, func(t *testing.T) {
+			databasetest.RunWithEnt(t, driver, func(client *ent.Client) {
+				ctx := context.Background()
+				defer client.User.Delete().ExecX(ctx)
+
+				client.User.Create().SetAttributes(enthstore.FromMap(map[string]string{
+					"a": "b",
+					"c": "d",
+				})).SaveX(ctx)
+				client.User.Create().SetAttributes(enthstore.FromMap(map[string]string{
+					"e": "f",
+				})).SaveX(ctx)
+
+				require.Equal(t, 2, client.User.Query().Where(user.AttributesHasAnyKeys("a", "e")).CountX(ctx))
+				require.Equal(t, 1, client.User.Query().Where(user.AttributesNotHasKey("a")).CountX(ctx))
+
+				contains := enthstore.FromMap(map[string]string{"a": "b"})
+				require.Equal(t, 1, client.User.Query().Where(user.AttributesContains(contains)).CountX(ctx))
+				require.Equal(t, 1, client.User.Query().Where(user.AttributesNotContains(contains)).CountX(ctx))
+
+				containedBy := enthstore.FromMap(map[string]string{"a": "b", "c": "d", "g": "h"})
+				require.Equal(t, 1, client.User.Query().Where(user.AttributesContainedBy(containedBy)).CountX(ctx))
+				require.Equal(t, 1, client.User.Query().Where(user.AttributesNotContainedBy(containedBy)).CountX(ctx))
+			})
+		})
+	}
+}
+
+func TestIntegrationHstoreCastValue(t *testing.T) {
+	for _, driver := range []string{"pgx", "postgres"} {
+		driver := driver
+		t.Run(driver, func(t *testing.T) {
+			databasetest.RunWithEnt(t, driver, func(client *ent.Client) {
+				ctx := context.Background()
+				defer client.User.Delete().ExecX(ctx)
+
+				for _, age := range []string{"9", "10", "100"} {
+					client.User.Create().SetAttributes(enthstore.FromMap(map[string]string{
+						"age":  age,
+						"born": "2022-01-0" + age[:1],
+					})).SaveX(ctx)
+				}
+
+				where := func(p func(s *sql.Selector) *sql.Predicate) func(*sql.Selector) {
+					return func(s *sql.Selector) {
+						s.Where(p(s))
+					}
+				}
+
+				require.Equal(t, 1, client.User.Query().Where(user.AttributesValueGT("age", "9")).CountX(ctx))
+				require.Equal(t, 2, client.User.Query().Where(where(func(s *sql.Selector) *sql.Predicate {
+					return enthstore.Value(s.C(user.FieldAttributes), "age").As(enthstore.Numeric).GT(9)
+				})).CountX(ctx))
+				require.Equal(t, 2, client.User.Query().Where(where(func(s *sql.Selector) *sql.Predicate {
+					return enthstore.Value(s.C(user.FieldAttributes), "age").As(enthstore.Integer).Between(9, 10)
+				})).CountX(ctx))
+				require.Equal(t, 2, client.User.Query().Where(where(func(s *sql.Selector) *sql.Predicate {
+					return enthstore.Value(s.C(user.FieldAttributes), "born").As(enthstore.Date).
+						LT(time.Date(2022, 1, 5, 0, 0, 0, 0, time.UTC))
+				})).CountX(ctx))
+
+				client.User.Create().SetAttributes(enthstore.FromMap(map[string]string{
+					"age": "unknown",
+				})).SaveX(ctx)
+
+				_, err := client.User.Query().Where(where(func(s *sql.Selector) *sql.Predicate {
+					return enthstore.Value(s.C(user.FieldAttributes), "age").As(enthstore.Numeric).GT(9)
+				})).Count(ctx)
+				require.Error(t, err)
+
+				require.Equal(t, 2, client.User.Query().Where(where(func(s *sql.Selector) *sql.Predicate {
+					return enthstore.Value(s.C(user.FieldAttributes), "age").As(enthstore.Numeric).Guard().GT(9)
+				})).CountX(ctx))
+			})
+		})
+	}
+}
+
+func TestIntegrationHstoreSelect(t *testing.T) {
+	for _, driver := range []string{"pgx", "postgres"} {
+		driver := driver
+		t.Run(driver, func(t *testing.T) {
+			databasetest.RunWithEnt(t, driver, func(client *ent.Client) {
+				ctx := context.Background()
+				defer client.User.Delete().ExecX(ctx)
+
+				u1 := client.User.Create().SetAttributes(enthstore.Hstore{
+					"a": enthstore.FromMap(map[string]string{"a": "b"})["a"],
+					"c": nil,
+				}).SaveX(ctx)
+				u2 := client.User.Create().SaveX(ctx)
+
+				var values []struct {
+					ID    int                 `json:"id"`
+					A     sql.NullString      `json:"a"`
+					Keys  enthstore.TextArray `json:"attributes_keys"`
+					Vals  enthstore.TextArray `json:"attributes_values"`
+					Slice enthstore.Hstore    `json:"attributes_slice"`
+					Size  int                 `json:"attributes_size"`
+				}
+				err := client.User.Query().
+					Order(ent.Asc(user.FieldID)).
+					Select(user.FieldID).
+					Modify(
+						enthstore.SelectValue(user.FieldAttributes, "a", "a"),
+						enthstore.SelectKeys(user.FieldAttributes),
+						enthstore.SelectValues(user.FieldAttributes),
+						enthstore.SelectSlice(user.FieldAttributes, "a", "x"),
+						enthstore.SelectSize(user.FieldAttributes),
+					).
+					Scan(ctx, &values)
+				require.NoError(t, err)
+				require.Len(t, values, 2)
+
+				require.Equal(t, u1.ID, values[0].ID)
+				require.Equal(t, "b", values[0].A.String)
+				require.ElementsMatch(t, []string{"a", "c"}, values[0].Keys.Strings())
+				require.ElementsMatch(t, []string{"b", ""}, values[0].Vals.Strings())
+				require.True(t, enthstore.FromMap(map[string]string{"a": "b"}).Equals(values[0].Slice))
+				require.Equal(t, 2, values[0].Size)
+
+				require.Equal(t, u2.ID, values[1].ID)
+				require.False(t, values[1].A.Valid)
+				require.Empty(t, values[1].Keys)
+				require.Empty(t, values[1].Slice)
+				require.Equal(t, 0, values[1].Size)
+
+				size, err := client.User.Query().
+					Where(user.ID(u1.ID)).
+					Modify(func(s *sql.Selector) {
+						s.Select()
+					}, enthstore.SelectSize(user.FieldAttributes)).
+					Int(ctx)
+				require.NoError(t, err)
+				require.Equal(t, 2, size)
+			})
+		})
+	}
+}
+
+func TestIntegrationHstoreOrderByValue(t *testing.T) {
+	for _, driver := range []string{"pgx", "postgres"} {
+		driver := driver
+		t.Run(driver, func(t *testing.T) {
+			databasetest.RunWithEnt(t, driver, func(client *ent.Client) {
+				ctx := context.Background()
+				defer client.User.Delete().ExecX(ctx)
+
+				var ids []int
+				for _, priority := range []string{"9", "10", "100"} {
+					u := client.User.Create().SetAttributes(enthstore.FromMap(map[string]string{
+						"priority": priority,
+					})).SaveX(ctx)
+					ids = append(ids, u.ID)
+				}
+
+				empty := client.User.Create().SaveX(ctx)
+				invalid := client.User.Create().SetAttributes(enthstore.FromMap(map[string]string{
+					"priority": "high",
+				})).SaveX(ctx)
+
+				order := func(opts ...enthstore.OrderOption) []int {
+					return client.User.Query().
+						Where(user.IDIn(append(ids, empty.ID)...)).
+						Order(enthstore.OrderByValue(user.FieldAttributes, "priority", opts...)).
+						IDsX(ctx)
+				}
+
+				require.Equal(t, []int{ids[1], ids[2], ids[0], empty.ID}, order())
+				require.Equal(t, []int{empty.ID, ids[0], ids[2], ids[1]}, order(enthstore.OrderDesc()))
+				require.Equal(t, []int{empty.ID, ids[0], ids[1], ids[2]}, order(
+					enthstore.OrderAs(enthstore.Numeric, false),
+					enthstore.OrderNullsFirst(),
+				))
+				require.Equal(t, []int{ids[2], ids[1], ids[0], empty.ID}, order(
+					enthstore.OrderAs(enthstore.Numeric, false),
+					enthstore.OrderDesc(),
+					enthstore.OrderNullsLast(),
+				))
+
+				require.Equal(t, []int{ids[0], ids[1], ids[2], empty.ID, invalid.ID}, client.User.Query().
+					Order(
+						enthstore.OrderByValue(user.FieldAttributes, "priority", enthstore.OrderAs(enthstore.Integer, true)),
+						ent.Asc(user.FieldID),
+					).
+					IDsX(ctx))
+			})
+		})
+	}
+}
+
+func TestIntegrationHstoreAggregations(t *testing.T) {
+	for _, driver := range []string{"pgx", "postgres"} {
+		driver := driver
+		t.Run(driver, func(t *testing.T) {
+			databasetest.RunWithEnt(t, driver, func(client *ent.Client) {
+				ctx := context.Background()
+				defer client.User.Delete().ExecX(ctx)
+
+				client.User.Create().SetAttributes(enthstore.FromMap(map[string]string{
+					"a": "b",
+					"c": "d",
+				})).SaveX(ctx)
+				client.User.Create().SetAttributes(enthstore.FromMap(map[string]string{
+					"a": "b",
+				})).SaveX(ctx)
+				client.User.Create().SetAttributes(enthstore.Hstore{
+					"a": nil,
+				}).SaveX(ctx)
+				client.User.Create().SaveX(ctx)
+
+				keys, err := enthstore.KeyFrequency(ctx, client.User.Query(), user.FieldAttributes)
+				require.NoError(t, err)
+				require.Equal(t, []enthstore.KeyStat{{Key: "a", Count: 3}, {Key: "c", Count: 1}}, keys)
+
+				keys, err = enthstore.KeyFrequency(ctx, client.User.Query().Where(user.AttributesHasKey("c")), user.FieldAttributes)
+				require.NoError(t, err)
+				require.Equal(t, []enthstore.KeyStat{{Key: "a", Count: 1}, {Key: "c", Count: 1}}, keys)
+
+				values, err := enthstore.ValueFrequency(ctx, client.User.Query(), user.FieldAttributes, "a")
+				require.NoError(t, err)
+				require.Len(t, values, 2)
+				require.Equal(t, "b", *values[0].Value)
+				require.Equal(t, 2, values[0].Count)
+				require.Nil(t, values[1].Value)
+				require.Equal(t, 1, values[1].Count)
+
+				sizes, err := enthstore.SizeFrequency(ctx, client.User.Query(), user.FieldAttributes)
+				require.NoError(t, err)
+				require.Equal(t, []enthstore.SizeStat{{Size: 0, Count: 1}, {Size: 1, Count: 2}, {Size: 2, Count: 1}}, sizes)
+			})
+		})
+	}
+}
+
+func TestIntegrationHstoreAuditHook(t *testing.T) {
+	for _, driver := range []string{"pgx", "postgres"} {
+		driver := driver
+		t.Run(driver, func(t *testing.T) {
+			databasetest.RunWithEnt(t, driver, func(client *ent.Client) {
+				ctx := enthstore.WithActor(context.Background(), "admin")
+				defer client.User.Delete().ExecX(ctx)
+
+				var entries []enthstore.AuditEntry
+				client.User.Use(enthstore.AuditHook(func(ctx context.Context, e []enthstore.AuditEntry) error {
+					entries = append(entries, e...)
+					return nil
+				}))
+
+				u := client.User.Create().SetAttributes(enthstore.FromMap(map[string]string{
+					"a": "b",
+				})).SaveX(ctx)
+				client.User.UpdateOne(u).SetAttributes(enthstore.FromMap(map[string]string{
+					"a": "c",
+				})).SaveX(ctx)
+
+				require.Len(t, entries, 2)
+				require.Equal(t, u.ID, entries[0].ID)
+				require.Equal(t, "admin", entries[0].Actor)
+				require.Equal(t, "User", entries[0].Type)
+				require.Equal(t, user.FieldAttributes, entries[0].Field)
+				require.True(t, enthstore.FromMap(map[string]string{"a": "b"}).Equals(entries[0].Patch.Added))
+
+				require.Equal(t, u.ID, entries[1].ID)
+				require.Equal(t, "b", *entries[1].Patch.Changed["a"].Old)
+				require.Equal(t, "c", *entries[1].Patch.Changed["a"].New)
+
+				entries = nil
+				client.User.Update().SetAttributes(enthstore.Hstore{}).ExecX(ctx)
+				require.Len(t, entries, 1)
+				require.Equal(t, u.ID, entries[0].ID)
+				require.Equal(t, ent.OpUpdate, entries[0].Op)
+				require.True(t, enthstore.FromMap(map[string]string{"a": "c"}).Equals(entries[0].Patch.Removed))
+			})
+		})
+	}
+}
+
+func TestIntegrationHstoreSchemaHook(t *testing.T) {
+	for _, driver := range []string{"pgx", "postgres"} {
+		driver := driver
+		t.Run(driver, func(t *testing.T) {
+			databasetest.RunWithEnt(t, driver, func(client *ent.Client) {
+				ctx := context.Background()
+				defer client.User.Delete().ExecX(ctx)
+
+				client.User.Use(enthstore.Schema{
+					Keys: map[string]enthstore.KeyRule{
+						"plan": {Required: true, Enum: []string{"free", "pro"}},
+					},
+				}.Hook(user.FieldAttributes))
+
+				u := client.User.Create().SetAttributes(enthstore.FromMap(map[string]string{
+					"plan": "free",
+				})).SaveX(ctx)
+
+				var verr *enthstore.ValidationError
+				_, err := client.User.Create().SetAttributes(enthstore.FromMap(map[string]string{
+					"plan": "gold",
+				})).Save(ctx)
+				require.ErrorAs(t, err, &verr)
+
+				_, err = client.User.UpdateOne(u).SetAttributes(enthstore.Hstore{}).Save(ctx)
+				require.ErrorAs(t, err, &verr)
+
+				require.Equal(t, 1, client.User.Query().CountX(ctx))
+			})
+		})
+	}
+}
+
+func TestIntegrationHstoreChecksOption(t *testing.T) {
+	for _, driver := range []string{"pgx", "postgres"} {
+		driver := driver
+		t.Run(driver, func(t *testing.T) {
+			databasetest.RunWithEnt(t, driver, func(client *ent.Client) {
+				ctx := context.Background()
+				defer client.User.Delete().ExecX(ctx)
+
+				s := enthstore.Schema{
+					Keys: map[string]enthstore.KeyRule{
+						"plan": {Required: true, Enum: []string{"free", "pro"}},
+						"age":  {Min: enthstore.Float(0), Nullable: true},
+					},
+					AllowUnknown: true,
+				}
+
+				// the second migration must not change the constraints.
+				for i := 0; i < 2; i++ {
+					require.NoError(t, client.Schema.Create(ctx, enthstore.ChecksOption(user.Table, user.FieldAttributes, s)))
+				}
+
+				for _, attributes := range []map[string]string{
+					{"plan": "free"},
+					{"plan": "pro", "age": "18", "other": "value"},
+				} {
+					h := enthstore.FromMap(attributes)
+					require.NoError(t, s.Validate(h))
+					client.User.Create().SetAttributes(h).SaveX(ctx)
+				}
+
+				for _, attributes := range []map[string]string{
+					{},
+					{"plan": "gold"},
+					{"plan": "free", "age": "-1"},
+					{"plan": "free", "age": "old"},
+				} {
+					h := enthstore.FromMap(attributes)
+					require.Error(t, s.Validate(h))
+					_, err := client.User.Create().SetAttributes(h).Save(ctx)
+					require.Error(t, err)
+				}
+
+				require.Equal(t, 2, client.User.Query().CountX(ctx))
+
+				// a changed rule must replace the constraint of the key.
+				s.Keys["plan"] = enthstore.KeyRule{Required: true, Enum: []string{"free", "pro", "gold"}}
+				require.NoError(t, client.Schema.Create(ctx, enthstore.ChecksOption(user.Table, user.FieldAttributes, s)))
+
+				client.User.Create().SetAttributes(enthstore.FromMap(map[string]string{"plan": "gold"})).SaveX(ctx)
+				_, err := client.User.Create().SetAttributes(enthstore.FromMap(map[string]string{"plan": "silver"})).Save(ctx)
+				require.Error(t, err)
+				require.Equal(t, 3, client.User.Query().CountX(ctx))
+			})
+		})
+	}
+}
+
+func TestIntegrationHstoreEnableHstoreOption(t *testing.T) {
+	for _, driver := range []string{"pgx", "postgres"} {
+		driver := driver
+		t.Run(driver, func(t *testing.T) {
+			databasetest.RunWithDatabase(t, driver, func(db *stdsql.DB, purgeDB func()) {
+				ctx := context.Background()
+
+				t.Run("diff", func(t *testing.T) {
+					purgeDB()
+					_, err := db.ExecContext(ctx, `DROP EXTENSION IF EXISTS hstore`)
+					require.NoError(t, err)
+
+					dir, err := atlasmigrate.NewLocalDir(t.TempDir())
+					require.NoError(t, err)
+
+					m, err := entschema.NewMigrate(sql.OpenDB(dialect.Postgres, db),
+						entschema.WithDir(dir),
+						entschema.WithFormatter(enthstore.ExtensionFormatter(sqltool.GolangMigrateFormatter)),
+						enthstore.EnableHstoreOption(),
+					)
+					require.NoError(t, err)
+					require.NoError(t, m.NamedDiff(ctx, "init", migrate.Tables...))
+
+					files, err := dir.Files()
+					require.NoError(t, err)
+					require.Len(t, files, 1)
+					require.Contains(t, string(files[0].Bytes()), `CREATE EXTENSION IF NOT EXISTS hstore WITH SCHEMA "public";`)
+				})
+
+				t.Run("create", func(t *testing.T) {
+					purgeDB()
+					_, err := db.ExecContext(ctx, `DROP EXTENSION IF EXISTS hstore`)
+					require.NoError(t, err)
+
+					client := ent.NewClient(ent.Driver(sql.OpenDB(dialect.Postgres, db)))
+					for i := 0; i < 2; i++ {
+						require.NoError(t, client.Schema.Create(ctx, enthstore.EnableHstoreOption()))
+					}
+
+					client.User.Create().SetAttributes(enthstore.FromMap(map[string]string{"a": "b"})).SaveX(ctx)
+					count := client.User.Query().Where(func(selector *sql.Selector) {
+						selector.Where(enthstore.HasKey(user.FieldAttributes, "a"))
+					}).CountX(ctx)
+					require.Equal(t, 1, count)
+				})
+			})
+		})
+	}
+}
+
+func TestIntegrationHstoreIndexesOption(t *testing.T) {
+	for _, driver := range []string{"pgx", "postgres"} {
+		driver := driver
+		t.Run(driver, func(t *testing.T) {
+			databasetest.RunWithDatabase(t, driver, func(db *stdsql.DB, purgeDB func()) {
+				purgeDB()
+				ctx := context.Background()
+
+				client := ent.NewClient(ent.Driver(sql.OpenDB(dialect.Postgres, db)))
+				// the second migration must not change the indexes.
+				for i := 0; i < 2; i++ {
+					require.NoError(t, client.Schema.Create(ctx,
+						enthstore.EnableHstoreOption(),
+						enthstore.IndexesOption(user.Table, schema.User{}),
+					))
+				}
+
+				conn, err := db.Conn(ctx)
+				require.NoError(t, err)
+				defer conn.Close()
+
+				_, err = conn.ExecContext(ctx, `SET enable_seqscan = off`)
+				require.NoError(t, err)
+
+				explain := func(p *sql.Predicate) string {
+					query, args := sql.Dialect(dialect.Postgres).
+						Select("*").
+						From(sql.Table(user.Table)).
+						Where(p).
+						Query()
+
+					rows, err := conn.QueryContext(ctx, "EXPLAIN "+query, args...)
+					require.NoError(t, err)
+					defer rows.Close()
+
+					var plan []string
+					for rows.Next() {
+						var line string
+						require.NoError(t, rows.Scan(&line))
+						plan = append(plan, line)
+					}
+
+					require.NoError(t, rows.Err())
+					return strings.Join(plan, "\n")
+				}
+
+				require.Contains(t, explain(enthstore.HasKey(user.FieldAttributes, "a")), "users_attributes_gin")
+				require.Contains(t, explain(enthstore.Contains(user.FieldAttributes, enthstore.FromMap(map[string]string{
+					"a": "b",
+				}))), "users_attributes_gin")
+				require.Contains(t, explain(enthstore.ValueEQ(user.FieldAttributes, "a", "b")), "users_attributes_a")
+			})
+		})
+	}
+}
+
+func TestSQLiteHstore(t *testing.T) {
+	ctx := context.Background()
+
+	drv, err := sql.Open(dialect.SQLite, "file:hstore?mode=memory&cache=shared&_fk=1")
+	require.NoError(t, err)
+
+	client := ent.NewClient(ent.Driver(enthstore.JSONDriver(drv)))
+	defer client.Close()
+	require.NoError(t, client.Schema.Create(ctx))
+
+	language := "pt"
+	rows := []enthstore.Hstore{
+		{},
+		{"a": ptr("b")},
+		{"a": ptr("c"), "b": nil},
+		{"a": nil, "c": ptr("d%e")},
+		{"a": ptr("bcd"), "b": ptr("B"), `k"ey`: ptr("é")},
+	}
+
+	ids := make(map[int]enthstore.Hstore, len(rows))
+	for _, h := range rows {
+		u := client.User.Create().
+			SetAttributes(h).
+			SetSettings(enthstore.NewTyped(schema.Settings{Theme: "dark", Language: &language})).
+			SaveX(ctx)
+		ids[u.ID] = h
+	}
+
+	users := client.User.Query().AllX(ctx)
+	require.Len(t, users, len(rows))
+	for _, u := range users {
+		require.True(t, ids[u.ID].Equals(u.Attributes))
+		require.Equal(t, schema.Settings{Theme: "dark", Language: &language}, u.Settings.Data)
+	}
+
+	preds := []*enthstore.Predicate{
+		enthstore.Matchable.HasKey(user.FieldAttributes, "a"),
+		enthstore.Matchable.HasKey(user.FieldAttributes, `k"ey`),
+		enthstore.Matchable.HasAllKeys(user.FieldAttributes, "a", "b"),
+		enthstore.Matchable.HasAnyKeys(user.FieldAttributes, "b", "c"),
+		enthstore.Matchable.NotHasKey(user.FieldAttributes, "a"),
+		enthstore.Matchable.Contains(user.FieldAttributes, enthstore.Hstore{"a": ptr("c"), "b": nil}),
+		enthstore.Matchable.Contains(user.FieldAttributes, enthstore.Hstore{"a": nil}),
+		enthstore.Matchable.NotContains(user.FieldAttributes, enthstore.Hstore{"a": ptr("b")}),
+		enthstore.Matchable.ContainedBy(user.FieldAttributes, enthstore.Hstore{"a": ptr("c"), "b": nil, "c": nil}),
+		enthstore.Matchable.ContainedBy(user.FieldAttributes, enthstore.Hstore{}),
+		enthstore.Matchable.NotContainedBy(user.FieldAttributes, enthstore.Hstore{"a": ptr("b")}),
+		enthstore.Matchable.ValueIsNull(user.FieldAttributes, "a"),
+		enthstore.Matchable.ValueEQ(user.FieldAttributes, "a", "b"),
+		enthstore.Matchable.ValueNEQ(user.FieldAttributes, "a", "b"),
+		enthstore.Matchable.ValueGT(user.FieldAttributes, "a", "b"),
+		enthstore.Matchable.ValueLTE(user.FieldAttributes, "b", "a"),
+		enthstore.Matchable.ValueContains(user.FieldAttributes, "c", "%"),
+		enthstore.Matchable.ValueContains(user.FieldAttributes, "a", "C"),
+		enthstore.Matchable.ValueHasPrefix(user.FieldAttributes, "a", "bc"),
+		enthstore.Matchable.ValueHasPrefix(user.FieldAttributes, "a", "B"),
+		enthstore.Matchable.ValueHasSuffix(user.FieldAttributes, "a", "cd"),
+		enthstore.Matchable.ValueHasSuffix(user.FieldAttributes, `k"ey`, "é"),
+		enthstore.Matchable.Not(enthstore.Matchable.ValueHasSuffix(user.FieldAttributes, "a", "")),
+		enthstore.Matchable.ValueIn(user.FieldAttributes, "a", "b", "c"),
+		enthstore.Matchable.ValueIn(user.FieldAttributes, "a"),
+		enthstore.Matchable.ValueNotIn(user.FieldAttributes, "a", "b"),
+		enthstore.Matchable.ValueNotIn(user.FieldAttributes, "a"),
+		enthstore.Matchable.AnyValueEQ(user.FieldAttributes, "B"),
+		enthstore.Matchable.AnyValueEQ(user.FieldAttributes, "é"),
+		enthstore.Matchable.AnyValueContains(user.FieldAttributes, "%"),
+		enthstore.Matchable.Not(enthstore.Matchable.AnyValueContains(user.FieldAttributes, "c")),
+		enthstore.Matchable.IsEmpty(user.FieldAttributes),
+		enthstore.Matchable.IsNotEmpty(user.FieldAttributes),
+		enthstore.Matchable.KeyCountEQ(user.FieldAttributes, 2),
+		enthstore.Matchable.KeyCountGT(user.FieldAttributes, 1),
+		enthstore.Matchable.KeyCountLTE(user.FieldAttributes, 1),
+		enthstore.Matchable.ValueEqualFold(user.FieldAttributes, "b", "b"),
+		enthstore.Matchable.ValueContainsFold(user.FieldAttributes, "a", "C"),
+		enthstore.Matchable.ValueContainsFold(user.FieldAttributes, "c", "%"),
+		enthstore.Matchable.ValueLike(user.FieldAttributes, "a", "_c%"),
+		enthstore.Matchable.ValueLike(user.FieldAttributes, "c", `d\%_`),
+		enthstore.Matchable.ValueNotLike(user.FieldAttributes, "a", "B%"),
+	}
+	for i, p := range preds {
+		var want []int
+		for id, h := range ids {
+			ok, err := enthstore.Match(p, h)
+			require.NoError(t, err)
+			if ok {
+				want = append(want, id)
+			}
+		}
+
+		got := client.User.Query().Where(func(s *sql.Selector) {
+			s.Where(p.Predicate)
+		}).IDsX(ctx)
+		require.ElementsMatch(t, want, got, "predicate %d", i)
+	}
+}
+
+func TestSQLiteWithoutJSONDriver(t *testing.T) {
+	ctx := context.Background()
+
+	drv, err := sql.Open(dialect.SQLite, "file:nojson?mode=memory&cache=shared&_fk=1")
+	require.NoError(t, err)
+
+	client := ent.NewClient(ent.Driver(drv))
+	defer client.Close()
+	require.NoError(t, client.Schema.Create(ctx))
+
+	// the hstore text representation is not stored on the JSON column.
+	_, err = client.User.Create().SetAttributes(enthstore.Hstore{"a": ptr("b")}).Save(ctx)
+	require.Error(t, err)
+
+	// the JSON objects are decoded without the JSONDriver.
+	u := ent.NewClient(ent.Driver(enthstore.JSONDriver(drv))).User.Create().
+		SetAttributes(enthstore.Hstore{"a": ptr("b"), "c": nil}).
+		SaveX(ctx)
+	got := client.User.GetX(ctx, u.ID)
+	require.True(t, enthstore.Hstore{"a": ptr("b"), "c": nil}.Equals(got.Attributes))
+}
+
+func TestSQLiteAuditHook(t *testing.T) {
+	ctx := context.Background()
+
+	drv, err := sql.Open(dialect.SQLite, "file:audit?mode=memory&cache=shared&_fk=1")
+	require.NoError(t, err)
+
+	client := ent.NewClient(ent.Driver(enthstore.JSONDriver(drv)))
+	defer client.Close()
+	require.NoError(t, client.Schema.Create(ctx))
+
+	var entries []enthstore.AuditEntry
+	client.User.Use(enthstore.AuditHook(func(ctx context.Context, e []enthstore.AuditEntry) error {
+		entries = append(entries, e...)
+		return nil
+	}))
+
+	u1 := client.User.Create().SetAttributes(enthstore.Hstore{"a": ptr("b")}).SaveX(ctx)
+	u2 := client.User.Create().SetAttributes(enthstore.Hstore{"a": ptr("c")}).SaveX(ctx)
+	u3 := client.User.Create().SetAttributes(enthstore.Hstore{"x": nil}).SaveX(ctx)
+
+	entries = nil
+	client.User.Update().
+		Where(user.IDIn(u1.ID, u2.ID, u3.ID)).
+		SetAttributes(enthstore.Hstore{"a": ptr("c")}).
+		ExecX(ctx)
+
+	require.Len(t, entries, 2)
+	require.Equal(t, u1.ID, entries[0].ID)
+	require.Equal(t, "b", *entries[0].Patch.Changed["a"].Old)
+	require.Equal(t, u3.ID, entries[1].ID)
+	require.True(t, enthstore.Hstore{"a": ptr("c")}.Equals(entries[1].Patch.Added))
+	require.True(t, enthstore.Hstore{"x": nil}.Equals(entries[1].Patch.Removed))
+}
+
+func ptr(s string) *string {
+	return &s
+}
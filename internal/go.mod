@@ -1,17 +1,19 @@
 module internal
 
-go 1.17
+go 1.18
 
 require (
-	entgo.io/ent v0.10.0
+	ariga.io/atlas v0.5.1-0.20220717122844-8593d7eb1a8e
+	entgo.io/ent v0.11.2
 	github.com/crossworth/enthstore v0.0.0-00010101000000-000000000000
+	github.com/crossworth/enthstore/pgxhstore v0.0.0-00010101000000-000000000000
 	github.com/jackc/pgx/v4 v4.14.1
-	github.com/lib/pq v1.10.4
+	github.com/lib/pq v1.10.5
+	github.com/mattn/go-sqlite3 v1.14.13
 	github.com/stretchr/testify v1.7.1-0.20210427113832-6241f9ab9942
 )
 
 require (
-	ariga.io/atlas v0.3.2-0.20220120225051-c3fac7d636dd // indirect
 	github.com/agext/levenshtein v1.2.1 // indirect
 	github.com/apparentlymart/go-textseg/v13 v13.0.0 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
@@ -29,10 +31,12 @@ require (
 	github.com/mitchellh/go-wordwrap v0.0.0-20150314170334-ad45545899c7 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/zclconf/go-cty v1.8.0 // indirect
-	golang.org/x/crypto v0.0.0-20210817164053-32db794688a5 // indirect
-	golang.org/x/mod v0.5.1 // indirect
+	golang.org/x/crypto v0.0.0-20210921155107-089bfa567519 // indirect
+	golang.org/x/mod v0.6.0-dev.0.20220419223038-86c51ed26bb4 // indirect
 	golang.org/x/text v0.3.7 // indirect
 	gopkg.in/yaml.v3 v3.0.0-20210107192922-496545a6307b // indirect
 )
 
 replace github.com/crossworth/enthstore => ../../enthstore
+
+replace github.com/crossworth/enthstore/pgxhstore => ../../enthstore/pgxhstore
@@ -1,12 +1,15 @@
 package hstore
 
 import (
+	"context"
 	"database/sql"
 	"testing"
 
 	"internal/databasetest"
 
 	"github.com/crossworth/enthstore"
+	"github.com/crossworth/enthstore/pgxhstore"
+	"github.com/jackc/pgx/v4"
 	"github.com/stretchr/testify/require"
 )
 
@@ -131,3 +134,63 @@ func TestIntegrationHstorePQ(t *testing.T) {
 		})
 	})
 }
+
+func TestIntegrationHstorePGXNative(t *testing.T) {
+	databasetest.RunWithPGX(t, func(conn *pgx.Conn) {
+		ctx := context.Background()
+
+		input := enthstore.FromMap(map[string]string{
+			"k1":       "v1",
+			"NOT NULL": "NULL",
+			"a'a":      `b'b`,
+			`"a"`:      `"b"`,
+			"hs":       "a=>b",
+			"a\tb":     "\nabc\t",
+			"empty":    "",
+		})
+		input.Set("NULL", nil)
+
+		t.Run("binary", func(t *testing.T) {
+			var output pgxhstore.Hstore
+			err := conn.QueryRow(ctx, "SELECT $1::hstore", input).Scan(&output)
+			require.NoError(t, err)
+			require.True(t, input.Equals(output.Map))
+		})
+
+		t.Run("text", func(t *testing.T) {
+			var output pgxhstore.Hstore
+			err := conn.QueryRow(ctx, "SELECT $1::hstore", pgx.QueryResultFormats{pgx.TextFormatCode}, input).Scan(&output)
+			require.NoError(t, err)
+			require.True(t, input.Equals(output.Map))
+		})
+
+		t.Run("null", func(t *testing.T) {
+			var output enthstore.Hstore
+			err := conn.QueryRow(ctx, "SELECT NULL::hstore").Scan(&output)
+			require.NoError(t, err)
+			require.Nil(t, output)
+		})
+
+		t.Run("scan into enthstore.Hstore", func(t *testing.T) {
+			var output enthstore.Hstore
+			err := conn.QueryRow(ctx, "SELECT $1::hstore", input).Scan(&output)
+			require.NoError(t, err)
+			require.True(t, input.Equals(output))
+		})
+
+		t.Run("copy", func(t *testing.T) {
+			_, err := conn.Exec(ctx, "CREATE TEMPORARY TABLE hstore_copy (attributes hstore)")
+			require.NoError(t, err)
+
+			rows := [][]interface{}{{input}, {enthstore.Hstore{}}, {nil}}
+			n, err := conn.CopyFrom(ctx, pgx.Identifier{"hstore_copy"}, []string{"attributes"}, pgx.CopyFromRows(rows))
+			require.NoError(t, err)
+			require.Equal(t, int64(len(rows)), n)
+
+			var count int
+			err = conn.QueryRow(ctx, "SELECT count(*) FROM hstore_copy WHERE attributes = $1", input).Scan(&count)
+			require.NoError(t, err)
+			require.Equal(t, 1, count)
+		})
+	})
+}
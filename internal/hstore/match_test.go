@@ -0,0 +1,170 @@
+package hstore
+
+import (
+	"database/sql"
+	"strconv"
+	"testing"
+	"time"
+
+	"internal/databasetest"
+
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/crossworth/enthstore"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIntegrationMatchConformance runs every predicate on Postgres and
+// with enthstore.Match, both must select the same rows.
+func TestIntegrationMatchConformance(t *testing.T) {
+	value := func(s string) *string {
+		return &s
+	}
+
+	rows := []enthstore.Hstore{
+		nil,
+		{},
+		{"a": value("b"), "c": value("d")},
+		{"a": nil, "age": value("9")},
+		{"a": value("bc"), "age": value("10"), "born": value("2022-01-02"), "admin": value("t")},
+		{"age": value("100"), "born": value("2021-12-31"), "at": value("2022-01-02 10:00:00+03"), "admin": value("no")},
+		{"age": value("unknown"), "like": value("50%_off")},
+	}
+
+	predicates := []*enthstore.Predicate{
+		enthstore.Matchable.HasKey("attributes", "a"),
+		enthstore.Matchable.NotHasKey("attributes", "a"),
+		enthstore.Matchable.HasAllKeys("attributes", "a", "c"),
+		enthstore.Matchable.HasAnyKeys("attributes", "c", "age"),
+		enthstore.Matchable.Contains("attributes", enthstore.Hstore{"a": value("b")}),
+		enthstore.Matchable.Contains("attributes", enthstore.Hstore{"a": nil}),
+		enthstore.Matchable.Contains("attributes", enthstore.Hstore{}),
+		enthstore.Matchable.NotContains("attributes", enthstore.Hstore{"a": value("b")}),
+		enthstore.Matchable.ContainedBy("attributes", enthstore.Hstore{"a": value("b"), "c": value("d"), "e": nil}),
+		enthstore.Matchable.NotContainedBy("attributes", enthstore.Hstore{"a": value("b"), "c": value("d")}),
+		enthstore.Matchable.ValueIsNull("attributes", "a"),
+		enthstore.Matchable.Not(enthstore.Matchable.ValueIsNull("attributes", "a")),
+		enthstore.Matchable.ValueEQ("attributes", "a", "b"),
+		enthstore.Matchable.ValueNEQ("attributes", "a", "b"),
+		enthstore.Matchable.ValueGT("attributes", "age", "9"),
+		enthstore.Matchable.ValueGTE("attributes", "age", "10"),
+		enthstore.Matchable.ValueLT("attributes", "age", "9"),
+		enthstore.Matchable.ValueLTE("attributes", "age", "100"),
+		enthstore.Matchable.ValueContains("attributes", "like", "%_"),
+		enthstore.Matchable.ValueHasPrefix("attributes", "a", "b"),
+		enthstore.Matchable.ValueHasSuffix("attributes", "a", "c"),
+		enthstore.Matchable.ValueIn("attributes", "a", "b", "bc"),
+		enthstore.Matchable.ValueIn("attributes", "a"),
+		enthstore.Matchable.ValueNotIn("attributes", "age", "9", "10"),
+		enthstore.Matchable.ValueNotIn("attributes", "age"),
+		enthstore.Matchable.Not(enthstore.Matchable.ValueIn("attributes", "a", "b")),
+		enthstore.Matchable.AnyValueEQ("attributes", "b"),
+		enthstore.Matchable.Not(enthstore.Matchable.AnyValueEQ("attributes", "9")),
+		enthstore.Matchable.AnyValueContains("attributes", "%_"),
+		enthstore.Matchable.Not(enthstore.Matchable.AnyValueContains("attributes", "20")),
+		enthstore.Matchable.KeyMatches("attributes", "^a[a-z]+$"),
+		enthstore.Matchable.Not(enthstore.Matchable.KeyMatches("attributes", "^b")),
+		enthstore.Matchable.IsEmpty("attributes"),
+		enthstore.Matchable.IsNotEmpty("attributes"),
+		enthstore.Matchable.Not(enthstore.Matchable.IsEmpty("attributes")),
+		enthstore.Matchable.KeyCountEQ("attributes", 2),
+		enthstore.Matchable.KeyCountGT("attributes", 2),
+		enthstore.Matchable.KeyCountLTE("attributes", 0),
+		enthstore.Matchable.KeyCountNEQ("attributes", 4),
+		enthstore.Matchable.ValueEqualFold("attributes", "admin", "T"),
+		enthstore.Matchable.ValueContainsFold("attributes", "like", "%_OFF"),
+		enthstore.Matchable.ValueLike("attributes", "born", "2022-__-%"),
+		enthstore.Matchable.ValueNotLike("attributes", "like", `50\%%`),
+		enthstore.Matchable.ValueRegex("attributes", "age", `^\d+$`),
+		enthstore.Matchable.ValueRegexFold("attributes", "like", "OFF$"),
+		enthstore.Matchable.ValueSimilarTo("attributes", "age", "[0-9]{2,3}"),
+		enthstore.Matchable.ValueSimilarTo("attributes", "a", "(b|c)%"),
+		enthstore.Matchable.Value("attributes", "age").As(enthstore.Numeric).Guard().GT(9),
+		enthstore.Matchable.Value("attributes", "age").As(enthstore.Integer).Guard().Between(9, 10),
+		enthstore.Matchable.Not(enthstore.Matchable.Value("attributes", "age").As(enthstore.Integer).Guard().LT(10)),
+		enthstore.Matchable.Value("attributes", "age").As(enthstore.Numeric).GT(9),
+		enthstore.Matchable.Value("attributes", "born").As(enthstore.Date).LT(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)),
+		enthstore.Matchable.Value("attributes", "at").As(enthstore.Timestamp).EQ(time.Date(2022, 1, 2, 7, 0, 0, 0, time.UTC)),
+		enthstore.Matchable.Value("attributes", "admin").As(enthstore.Boolean).EQ(true),
+		enthstore.Matchable.And(enthstore.Matchable.HasKey("attributes", "a"), enthstore.Matchable.ValueNEQ("attributes", "a", "b")),
+		enthstore.Matchable.Or(enthstore.Matchable.ValueIsNull("attributes", "a"), enthstore.Matchable.ValueEQ("attributes", "c", "d")),
+		enthstore.Matchable.Not(enthstore.Matchable.Or(enthstore.Matchable.HasKey("attributes", "a"), enthstore.Matchable.ValueEQ("attributes", "age", "9"))),
+	}
+
+	for _, driver := range []string{"pgx", "postgres"} {
+		driver := driver
+		t.Run(driver, func(t *testing.T) {
+			databasetest.RunWithDatabase(t, driver, func(db *sql.DB, purgeDB func()) {
+				defer purgeDB()
+
+				_, err := db.Exec(`
+CREATE EXTENSION IF NOT EXISTS hstore WITH SCHEMA public;
+CREATE TABLE conformance (id int PRIMARY KEY, attributes hstore);
+`)
+				require.NoError(t, err)
+
+				for i, row := range rows {
+					_, err := db.Exec("INSERT INTO conformance (id, attributes) VALUES ($1, $2::hstore)", i, row)
+					require.NoError(t, err)
+				}
+
+				for i, p := range predicates {
+					p := p
+					t.Run(strconv.Itoa(i), func(t *testing.T) {
+						var want []int
+						var wantErr error
+						for id, row := range rows {
+							ok, err := enthstore.Match(p, row)
+							if err != nil {
+								wantErr = err
+								break
+							}
+
+							if ok {
+								want = append(want, id)
+							}
+						}
+
+						query, args := entsql.Dialect("postgres").
+							Select("id").
+							From(entsql.Table("conformance")).
+							Where(p.Predicate).
+							OrderBy("id").
+							Query()
+
+						got, err := queryIDs(db, query, args...)
+						if wantErr != nil {
+							require.Error(t, err, query)
+							return
+						}
+
+						require.NoError(t, err, query)
+						require.Equal(t, want, got, query)
+					})
+				}
+			})
+		})
+	}
+}
+
+func queryIDs(db *sql.DB, query string, args ...interface{}) ([]int, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
@@ -0,0 +1,336 @@
+package enthstore
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidTarget is the error returned by Marshal and Unmarshal
+// when the value provided is not a struct or a pointer to a struct.
+var ErrInvalidTarget = errors.New("value must be a struct or a pointer to a struct")
+
+// MappingError is the error returned by Marshal and Unmarshal
+// when a struct field cannot be converted.
+type MappingError struct {
+	// Field is the name of the struct field.
+	Field string
+	// Key is the Hstore key of the field.
+	Key string
+	// Type is the type of the struct field.
+	Type reflect.Type
+	// Err is the underlying error.
+	Err error
+}
+
+// Error implements the interface error.
+func (e *MappingError) Error() string {
+	return fmt.Sprintf("hstore: cannot convert key %q of field %s (%s): %v", e.Key, e.Field, e.Type, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *MappingError) Unwrap() error {
+	return e.Err
+}
+
+var (
+	timeType            = reflect.TypeOf(time.Time{})
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// Marshal converts a struct to an Hstore.
+//
+// Every exported field is stored using the field name as key,
+// unless the field has a tag in the format `hstore:"name,omitempty"`,
+// the "omitempty" option skips fields with zero value and the name "-"
+// skips the field. Nil pointers are stored as NULL values. The fields of
+// the embedded structs, or pointers to structs, without a tag are stored
+// like the fields of the struct, a nil embedded pointer is skipped.
+//
+// Strings, booleans, integers, floats, time.Time (RFC 3339), time.Duration
+// and types implementing encoding.TextMarshaler are supported.
+func Marshal(v interface{}) (Hstore, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return nil, ErrInvalidTarget
+	}
+
+	hs := Hstore{}
+	err := walkFields(rv, nil, func(f reflect.StructField, fv reflect.Value, opts fieldOptions) error {
+		if opts.omitEmpty && fv.IsZero() {
+			return nil
+		}
+
+		if fv.Kind() == reflect.Ptr {
+			if fv.IsNil() {
+				hs.Set(opts.key, nil)
+				return nil
+			}
+
+			fv = fv.Elem()
+		}
+
+		val, err := formatValue(fv)
+		if err != nil {
+			return &MappingError{Field: f.Name, Key: opts.key, Type: f.Type, Err: err}
+		}
+
+		hs.SetString(opts.key, val)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return hs, nil
+}
+
+// Unmarshal converts an Hstore to the struct pointed by v, following
+// the same rules of Marshal. Keys not present on the Hstore leave
+// the field untouched and NULL values set pointer fields to nil.
+func Unmarshal(h Hstore, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return ErrInvalidTarget
+	}
+
+	// the nil embedded pointers are only set when
+	// the Hstore has a key of their fields.
+	var alloc func(v reflect.Value) bool
+	alloc = func(v reflect.Value) bool {
+		found := false
+		_ = walkFields(v, alloc, func(_ reflect.StructField, _ reflect.Value, opts fieldOptions) error {
+			_, ok := h[opts.key]
+			found = found || ok
+			return nil
+		})
+
+		return found
+	}
+
+	return walkFields(rv.Elem(), alloc, func(f reflect.StructField, fv reflect.Value, opts fieldOptions) error {
+		val, found := h[opts.key]
+		if !found {
+			return nil
+		}
+
+		if val == nil {
+			if fv.Kind() == reflect.Ptr {
+				fv.Set(reflect.Zero(fv.Type()))
+			}
+
+			return nil
+		}
+
+		if fv.Kind() == reflect.Ptr {
+			ptr := reflect.New(fv.Type().Elem())
+			if err := parseValue(ptr.Elem(), *val); err != nil {
+				return &MappingError{Field: f.Name, Key: opts.key, Type: f.Type, Err: err}
+			}
+
+			fv.Set(ptr)
+			return nil
+		}
+
+		if err := parseValue(fv, *val); err != nil {
+			return &MappingError{Field: f.Name, Key: opts.key, Type: f.Type, Err: err}
+		}
+
+		return nil
+	})
+}
+
+type fieldOptions struct {
+	key       string
+	omitEmpty bool
+}
+
+// walkFields calls fn for every mapped field of the struct, the fields of
+// embedded structs and pointers to structs without a tag are flattened. The
+// nil embedded pointers are skipped, unless alloc reports that the new struct
+// it's called with must be set, like when it has the keys being unmarshaled.
+func walkFields(
+	rv reflect.Value,
+	alloc func(reflect.Value) bool,
+	fn func(reflect.StructField, reflect.Value, fieldOptions) error,
+) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		tag, hasTag := f.Tag.Lookup("hstore")
+
+		if f.Anonymous && !hasTag && isStruct(f.Type) {
+			fv := rv.Field(i)
+			if fv.Kind() == reflect.Ptr {
+				if fv.IsNil() {
+					// the pointers to unexported structs can't be set.
+					if alloc == nil || !fv.CanSet() {
+						continue
+					}
+
+					ptr := reflect.New(f.Type.Elem())
+					if !alloc(ptr.Elem()) {
+						continue
+					}
+
+					fv.Set(ptr)
+				}
+
+				fv = fv.Elem()
+			}
+
+			if err := walkFields(fv, alloc, fn); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if f.PkgPath != "" || tag == "-" {
+			continue
+		}
+
+		opts := fieldOptions{key: f.Name}
+		if hasTag {
+			name, flags, _ := strings.Cut(tag, ",")
+			if name != "" {
+				opts.key = name
+			}
+
+			for flags != "" {
+				var flag string
+				flag, flags, _ = strings.Cut(flags, ",")
+				if flag == "omitempty" {
+					opts.omitEmpty = true
+				}
+			}
+		}
+
+		if err := fn(f, rv.Field(i), opts); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isStruct reports whether t is a struct or a pointer to a struct.
+func isStruct(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return t.Kind() == reflect.Struct
+}
+
+// formatValue returns the string form of a value.
+func formatValue(v reflect.Value) (string, error) {
+	switch v.Type() {
+	case timeType:
+		t, _ := v.Interface().(time.Time)
+		return t.Format(time.RFC3339Nano), nil
+	case durationType:
+		d, _ := v.Interface().(time.Duration)
+		return d.String(), nil
+	}
+
+	if v.CanAddr() && reflect.PtrTo(v.Type()).Implements(textMarshalerType) {
+		v = v.Addr()
+	}
+
+	if m, ok := v.Interface().(encoding.TextMarshaler); ok {
+		b, err := m.MarshalText()
+		return string(b), err
+	}
+
+	//nolint:exhaustive // the remaining kinds are not supported.
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), nil
+	}
+
+	return "", fmt.Errorf("unsupported type %s", v.Type())
+}
+
+// parseValue parses the string form of a value into v.
+func parseValue(v reflect.Value, s string) error {
+	switch v.Type() {
+	case timeType:
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return err
+		}
+
+		v.Set(reflect.ValueOf(t))
+		return nil
+	case durationType:
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	if reflect.PtrTo(v.Type()).Implements(textUnmarshalerType) {
+		u, _ := v.Addr().Interface().(encoding.TextUnmarshaler)
+		return u.UnmarshalText([]byte(s))
+	}
+
+	//nolint:exhaustive // the remaining kinds are not supported.
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+
+	return nil
+}
@@ -0,0 +1,177 @@
+package enthstore
+
+import (
+	"errors"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type marshalEmbedded struct {
+	Region string `hstore:"region"`
+}
+
+type marshalStruct struct {
+	marshalEmbedded
+	Name     string        `hstore:"name"`
+	Age      int           `hstore:"age"`
+	Score    float64       `hstore:"score"`
+	Count    uint8         `hstore:"count"`
+	Active   bool          `hstore:"active"`
+	Born     time.Time     `hstore:"born"`
+	Timeout  time.Duration `hstore:"timeout"`
+	IP       net.IP        `hstore:"ip"`
+	Nickname *string       `hstore:"nickname"`
+	Level    *int          `hstore:"level,omitempty"`
+	Note     string        `hstore:",omitempty"`
+	Ignored  string        `hstore:"-"`
+	Untagged string
+	private  string
+}
+
+func TestMarshal(t *testing.T) {
+	t.Parallel()
+
+	level := 3
+	in := marshalStruct{
+		marshalEmbedded: marshalEmbedded{Region: "sa"},
+		Name:            "name",
+		Age:             -10,
+		Score:           1.5,
+		Count:           255,
+		Active:          true,
+		Born:            time.Date(2020, 1, 2, 3, 4, 5, 6, time.UTC),
+		Timeout:         90 * time.Second,
+		IP:              net.ParseIP("127.0.0.1"),
+		Level:           &level,
+		Ignored:         "ignored",
+		Untagged:        "untagged",
+		private:         "private",
+	}
+
+	hs, err := Marshal(&in)
+	require.NoError(t, err)
+
+	want := FromMap(map[string]string{
+		"region":   "sa",
+		"name":     "name",
+		"age":      "-10",
+		"score":    "1.5",
+		"count":    "255",
+		"active":   "true",
+		"born":     "2020-01-02T03:04:05.000000006Z",
+		"timeout":  "1m30s",
+		"ip":       "127.0.0.1",
+		"level":    "3",
+		"Untagged": "untagged",
+	})
+	want.Set("nickname", nil)
+	require.True(t, want.Equals(hs), hs.String())
+
+	var out marshalStruct
+	require.NoError(t, Unmarshal(hs, &out))
+
+	in.Ignored = ""
+	in.private = ""
+	require.Equal(t, in, out)
+}
+
+func TestMarshal_invalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := Marshal(1)
+	require.ErrorIs(t, err, ErrInvalidTarget)
+
+	_, err = Marshal((*marshalStruct)(nil))
+	require.ErrorIs(t, err, ErrInvalidTarget)
+
+	_, err = Marshal(struct{ Values []string }{Values: []string{"a"}})
+	var mappingErr *MappingError
+	require.True(t, errors.As(err, &mappingErr))
+	require.Equal(t, "Values", mappingErr.Field)
+	require.EqualError(t, err, `hstore: cannot convert key "Values" of field Values ([]string): unsupported type []string`)
+}
+
+func TestUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	t.Run("null and missing keys", func(t *testing.T) {
+		t.Parallel()
+		nickname := "nick"
+		out := marshalStruct{Name: "keep", Nickname: &nickname, Age: 10}
+
+		hs := FromMap(map[string]string{"age": "20"})
+		hs.Set("nickname", nil)
+		hs.Set("name", nil)
+
+		require.NoError(t, Unmarshal(hs, &out))
+		require.Equal(t, "keep", out.Name)
+		require.Equal(t, 20, out.Age)
+		require.Nil(t, out.Nickname)
+	})
+
+	t.Run("invalid target", func(t *testing.T) {
+		t.Parallel()
+		var out marshalStruct
+		require.ErrorIs(t, Unmarshal(Hstore{}, out), ErrInvalidTarget)
+		require.ErrorIs(t, Unmarshal(Hstore{}, (*marshalStruct)(nil)), ErrInvalidTarget)
+	})
+
+	tests := []struct {
+		key     string
+		val     string
+		wantErr string
+	}{
+		{key: "age", val: "a", wantErr: `hstore: cannot convert key "age" of field Age (int): strconv.ParseInt: parsing "a": invalid syntax`},
+		{key: "count", val: "256", wantErr: `hstore: cannot convert key "count" of field Count (uint8): strconv.ParseUint: parsing "256": value out of range`},
+		{key: "active", val: "yes", wantErr: `hstore: cannot convert key "active" of field Active (bool): strconv.ParseBool: parsing "yes": invalid syntax`},
+		{key: "timeout", val: "1", wantErr: `hstore: cannot convert key "timeout" of field Timeout (time.Duration): time: missing unit in duration "1"`},
+		{key: "level", val: "1.5", wantErr: `hstore: cannot convert key "level" of field Level (*int): strconv.ParseInt: parsing "1.5": invalid syntax`},
+		{key: "ip", val: "ip", wantErr: `hstore: cannot convert key "ip" of field IP (net.IP): invalid IP address: ip`},
+	}
+	for i, tt := range tests {
+		tt := tt
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+			var out marshalStruct
+			err := Unmarshal(FromMap(map[string]string{tt.key: tt.val}), &out)
+
+			var mappingErr *MappingError
+			require.True(t, errors.As(err, &mappingErr))
+			require.Equal(t, tt.key, mappingErr.Key)
+			require.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+type MarshalPointerEmbedded struct {
+	Zone string `hstore:"zone"`
+}
+
+type marshalOptions struct {
+	*MarshalPointerEmbedded
+	Name  string `hstore:"name,omitempty,string"`
+	Level int    `hstore:",string,omitempty"`
+}
+
+func TestMarshal_options(t *testing.T) {
+	t.Parallel()
+
+	hs, err := Marshal(marshalOptions{})
+	require.NoError(t, err)
+	require.Equal(t, Hstore{}, hs)
+
+	hs, err = Marshal(marshalOptions{MarshalPointerEmbedded: &MarshalPointerEmbedded{Zone: "a"}, Name: "b", Level: 1})
+	require.NoError(t, err)
+	require.Equal(t, FromMap(map[string]string{"zone": "a", "name": "b", "Level": "1"}), hs)
+
+	var out marshalOptions
+	require.NoError(t, Unmarshal(FromMap(map[string]string{"name": "b"}), &out))
+	require.Nil(t, out.MarshalPointerEmbedded)
+
+	require.NoError(t, Unmarshal(FromMap(map[string]string{"zone": "a"}), &out))
+	require.Equal(t, &MarshalPointerEmbedded{Zone: "a"}, out.MarshalPointerEmbedded)
+}
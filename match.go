@@ -0,0 +1,496 @@
+package enthstore
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+)
+
+// ErrUnsupportedPredicate is the error returned by Match when
+// the Predicate was not created by this package.
+var ErrUnsupportedPredicate = errors.New("hstore: predicate cannot be evaluated")
+
+// truth is the result of a predicate using the three-valued
+// logic of SQL, a predicate on a NULL value is unknown.
+type truth int8
+
+const (
+	unknown truth = iota
+	isFalse
+	isTrue
+)
+
+func truthOf(b bool) truth {
+	if b {
+		return isTrue
+	}
+
+	return isFalse
+}
+
+// matcher evaluates a predicate against the Hstore of a row.
+type matcher func(h Hstore) (truth, error)
+
+// Predicate is a predicate returned by Matchable, it's written as SQL by
+// the embedded sql.Predicate and evaluated in Go by Match.
+//
+//	p := enthstore.Matchable.HasKey(user.FieldAttributes, "a")
+//	ok, err := enthstore.Match(p, enthstore.Hstore{"a": nil})
+//	client.User.Query().Where(func(s *sql.Selector) { s.Where(p.Predicate) })
+type Predicate struct {
+	*sql.Predicate
+	match matcher
+}
+
+// MatchablePredicates holds the predicates of this package in the form
+// evaluable by Match, every predicate function, like HasKey, has a method
+// with the same name and arguments that returns a *Predicate.
+type MatchablePredicates struct{}
+
+// Matchable creates the predicates evaluable by Match.
+var Matchable MatchablePredicates
+
+// newPredicate returns a Predicate evaluated by the matcher.
+func newPredicate(p *sql.Predicate, m matcher) *Predicate {
+	return &Predicate{Predicate: p, match: m}
+}
+
+// Match reports whether the Hstore satisfies the predicate, the predicate
+// is evaluated in Go with the same semantics of Postgres, so the predicates
+// can be tested without a database. A nil Hstore is handled as a NULL column.
+//
+// Only the predicates created by Matchable can be evaluated, use its And,
+// Or and Not methods to combine them. The text values are compared byte by byte, like the
+// "C" collation, and the timestamps without time zone are read as UTC.
+func Match(p *Predicate, h Hstore) (bool, error) {
+	t, err := p.eval(h)
+	return t == isTrue, err
+}
+
+// And groups predicates with the AND operator, like sql.And,
+// keeping the predicates evaluable by Match.
+func (MatchablePredicates) And(preds ...*Predicate) *Predicate {
+	return newPredicate(sql.And(sqlPredicates(preds)...), func(h Hstore) (truth, error) {
+		result := isTrue
+		for _, p := range preds {
+			t, err := p.eval(h)
+			if err != nil {
+				return unknown, err
+			}
+
+			if t == isFalse {
+				return isFalse, nil
+			}
+
+			if t == unknown {
+				result = unknown
+			}
+		}
+
+		return result, nil
+	})
+}
+
+// Or groups predicates with the OR operator, like sql.Or,
+// keeping the predicates evaluable by Match.
+func (MatchablePredicates) Or(preds ...*Predicate) *Predicate {
+	return newPredicate(sql.Or(sqlPredicates(preds)...), func(h Hstore) (truth, error) {
+		result := isFalse
+		for _, p := range preds {
+			t, err := p.eval(h)
+			if err != nil {
+				return unknown, err
+			}
+
+			if t == isTrue {
+				return isTrue, nil
+			}
+
+			if t == unknown {
+				result = unknown
+			}
+		}
+
+		return result, nil
+	})
+}
+
+// Not wraps the predicate with the NOT operator, like sql.Not,
+// keeping the predicate evaluable by Match.
+func (MatchablePredicates) Not(pred *Predicate) *Predicate {
+	return newPredicate(sql.Not(pred.Predicate), func(h Hstore) (truth, error) {
+		t, err := pred.eval(h)
+		if err != nil {
+			return unknown, err
+		}
+
+		switch t {
+		case isTrue:
+			return isFalse, nil
+		case isFalse:
+			return isTrue, nil
+		case unknown:
+		}
+
+		return unknown, nil
+	})
+}
+
+// eval evaluates the predicate, a Predicate created
+// outside of this package can't be evaluated.
+func (p *Predicate) eval(h Hstore) (truth, error) {
+	if p == nil || p.match == nil {
+		return unknown, ErrUnsupportedPredicate
+	}
+
+	return p.match(h)
+}
+
+func sqlPredicates(preds []*Predicate) []*sql.Predicate {
+	ps := make([]*sql.Predicate, len(preds))
+	for i, p := range preds {
+		ps[i] = p.Predicate
+	}
+
+	return ps
+}
+
+// matchKeys returns a matcher that handles a NULL column as unknown.
+func matchKeys(fn func(h Hstore) bool) matcher {
+	return func(h Hstore) (truth, error) {
+		if h == nil {
+			return unknown, nil
+		}
+
+		return truthOf(fn(h)), nil
+	}
+}
+
+// matchValue returns a matcher that handles a NULL column,
+// a missing key and a NULL value as unknown.
+func matchValue(key string, fn func(val string) (truth, error)) matcher {
+	return func(h Hstore) (truth, error) {
+		val := h.Get(key)
+		if val == nil {
+			return unknown, nil
+		}
+
+		return fn(*val)
+	}
+}
+
+// matchText returns a matcher comparing the value of the key as text.
+func matchText(key string, op sql.Op, other string) matcher {
+	return matchValue(key, func(val string) (truth, error) {
+		return truthOf(compareOp(op, strings.Compare(val, other))), nil
+	})
+}
+
+// matchIn returns a matcher checking if the value of the key is in the list,
+// negated by not. The empty list is not unknown, even for a NULL value.
+func matchIn(key string, vals []string, not bool) matcher {
+	return func(h Hstore) (truth, error) {
+		if len(vals) == 0 {
+			return truthOf(not), nil
+		}
+
+		return matchValue(key, func(val string) (truth, error) {
+			for _, v := range vals {
+				if v == val {
+					return truthOf(!not), nil
+				}
+			}
+
+			return truthOf(not), nil
+		})(h)
+	}
+}
+
+// matchRegex returns a matcher that searches the regular expression on the value
+// of the key. The expression is compiled by the regexp package, the syntax not
+// shared with the regular expressions of Postgres returns an error.
+func matchRegex(key string, pattern string) matcher {
+	re, err := regexp.Compile(pattern)
+	return matchValue(key, func(val string) (truth, error) {
+		if err != nil {
+			return unknown, fmt.Errorf("hstore: invalid regular expression: %w", err)
+		}
+
+		return truthOf(re.MatchString(val)), nil
+	})
+}
+
+// matchLike returns a matcher of the LIKE pattern, negated by not.
+func matchLike(key string, pattern string, not bool) matcher {
+	re, err := likeRegexp(pattern)
+	return matchValue(key, func(val string) (truth, error) {
+		if err != nil {
+			return unknown, err
+		}
+
+		return truthOf(re.MatchString(val) != not), nil
+	})
+}
+
+// matchSimilarTo returns a matcher of the SIMILAR TO pattern.
+func matchSimilarTo(key string, pattern string) matcher {
+	re, err := similarRegexp(pattern)
+	return matchValue(key, func(val string) (truth, error) {
+		if err != nil {
+			return unknown, err
+		}
+
+		return truthOf(re.MatchString(val)), nil
+	})
+}
+
+// likeRegexp converts a LIKE pattern with the default escape character to an
+// anchored regular expression.
+func likeRegexp(pattern string) (*regexp.Regexp, error) {
+	var sb strings.Builder
+	escaped := false
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			escaped = false
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		case r == '\\':
+			escaped = true
+		case r == '%':
+			sb.WriteString(".*")
+		case r == '_':
+			sb.WriteString(".")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+
+	if escaped {
+		return nil, fmt.Errorf("hstore: LIKE pattern must not end with escape character: %q", pattern)
+	}
+
+	return regexp.Compile("^(?s:" + sb.String() + ")$")
+}
+
+// similarRegexp converts a SIMILAR TO pattern with the default escape character
+// to an anchored regular expression, like the similar_escape function of Postgres.
+func similarRegexp(pattern string) (*regexp.Regexp, error) {
+	var sb strings.Builder
+	escaped, bracket := false, false
+	runes := []rune(pattern)
+	for i, r := range runes {
+		switch {
+		case escaped:
+			escaped = false
+			if bracket {
+				sb.WriteRune('\\')
+				sb.WriteRune(r)
+				continue
+			}
+
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		case r == '\\':
+			escaped = true
+		case bracket:
+			// a "]" right after the opening bracket is a literal.
+			if r == ']' && runes[i-1] != '[' && (runes[i-1] != '^' || runes[i-2] != '[') {
+				bracket = false
+			}
+
+			sb.WriteRune(r)
+		case r == '[':
+			bracket = true
+			sb.WriteRune(r)
+		case r == '%':
+			sb.WriteString(".*")
+		case r == '_':
+			sb.WriteString(".")
+		case r == '.' || r == '^' || r == '$':
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		default:
+			sb.WriteRune(r)
+		}
+	}
+
+	if escaped {
+		return nil, fmt.Errorf("hstore: SIMILAR TO pattern must not end with escape character: %q", pattern)
+	}
+
+	re, err := regexp.Compile("^(?s:" + sb.String() + ")$")
+	if err != nil {
+		return nil, fmt.Errorf("hstore: invalid SIMILAR TO pattern: %w", err)
+	}
+
+	return re, nil
+}
+
+// contains reports whether h contains all the pairs of other.
+func contains(h, other Hstore) bool {
+	for key, v2 := range other {
+		v1, found := h[key]
+		if !found || (v1 == nil) != (v2 == nil) {
+			return false
+		}
+
+		if v1 != nil && *v1 != *v2 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// compareOp applies the operator to the result of a comparison.
+func compareOp(op sql.Op, c int) bool {
+	//nolint:exhaustive // only the comparison operators are used.
+	switch op {
+	case sql.OpEQ:
+		return c == 0
+	case sql.OpNEQ:
+		return c != 0
+	case sql.OpGT:
+		return c > 0
+	case sql.OpGTE:
+		return c >= 0
+	case sql.OpLT:
+		return c < 0
+	case sql.OpLTE:
+		return c <= 0
+	}
+
+	return false
+}
+
+// castPatterns holds the compiled patterns of the value types.
+var castPatterns sync.Map
+
+func (t ValueType) matches(s string) bool {
+	re, ok := castPatterns.Load(t.name)
+	if !ok {
+		re, _ = castPatterns.LoadOrStore(t.name, regexp.MustCompile("(?i)"+t.pattern))
+	}
+
+	r, _ := re.(*regexp.Regexp)
+	return r.MatchString(s)
+}
+
+// compare casts the text value and the argument to the type and compares them.
+func (t ValueType) compare(val string, arg interface{}) (int, error) {
+	a, err := t.parse(val)
+	if err != nil {
+		return 0, err
+	}
+
+	b, err := t.convert(arg)
+	if err != nil {
+		return 0, err
+	}
+
+	switch a := a.(type) {
+	case *big.Rat:
+		b, _ := b.(*big.Rat)
+		return a.Cmp(b), nil
+	case time.Time:
+		b, _ := b.(time.Time)
+		switch {
+		case a.Before(b):
+			return -1, nil
+		case a.After(b):
+			return 1, nil
+		}
+
+		return 0, nil
+	case bool:
+		b, _ := b.(bool)
+		switch {
+		case a == b:
+			return 0, nil
+		case b:
+			return -1, nil
+		}
+
+		return 1, nil
+	}
+
+	return 0, fmt.Errorf("hstore: unsupported type %s", t.name)
+}
+
+// parse casts a text value, the values are validated
+// using the pattern of the type, like the guard.
+func (t ValueType) parse(s string) (interface{}, error) {
+	if !t.matches(s) {
+		return nil, fmt.Errorf("hstore: invalid input syntax for type %s: %q", t.name, s)
+	}
+
+	s = strings.TrimSpace(s)
+	switch t.name {
+	case Numeric.name, Integer.name:
+		r, ok := new(big.Rat).SetString(s)
+		if !ok {
+			return nil, fmt.Errorf("hstore: invalid input syntax for type %s: %q", t.name, s)
+		}
+
+		return r, nil
+	case Date.name:
+		return time.Parse("2006-01-02", s)
+	case Timestamp.name:
+		return parseTimestamp(s)
+	case Boolean.name:
+		switch strings.ToLower(s) {
+		case "t", "true", "y", "yes", "on", "1":
+			return true, nil
+		}
+
+		return false, nil
+	}
+
+	return nil, fmt.Errorf("hstore: unsupported type %s", t.name)
+}
+
+// convert converts an argument of a comparison to the type.
+func (t ValueType) convert(arg interface{}) (interface{}, error) {
+	switch t.name {
+	case Numeric.name, Integer.name:
+		switch arg := arg.(type) {
+		case float32:
+			return new(big.Rat).SetFloat64(float64(arg)), nil
+		case float64:
+			return new(big.Rat).SetFloat64(arg), nil
+		}
+	case Date.name, Timestamp.name:
+		if arg, ok := arg.(time.Time); ok {
+			return arg, nil
+		}
+	case Boolean.name:
+		if arg, ok := arg.(bool); ok {
+			return arg, nil
+		}
+	}
+
+	return t.parse(fmt.Sprint(arg))
+}
+
+// parseTimestamp parses the ISO 8601 formats accepted by the Timestamp guard.
+func parseTimestamp(s string) (time.Time, error) {
+	s = strings.ToUpper(s)
+	if len(s) > 10 && s[10] == ' ' {
+		s = s[:10] + "T" + s[11:]
+	}
+
+	s = strings.ReplaceAll(s, " ", "")
+	for _, clock := range []string{"", "T15:04", "T15:04:05"} {
+		for _, zone := range []string{"", "Z07:00", "Z0700", "Z07"} {
+			if t, err := time.Parse("2006-01-02"+clock+zone, s); err == nil {
+				return t, nil
+			}
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("hstore: invalid input syntax for type timestamptz: %q", s)
+}
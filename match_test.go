@@ -0,0 +1,169 @@
+package enthstore
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatch(t *testing.T) {
+	t.Parallel()
+
+	row := Hstore{
+		"a":     ptrString("b"),
+		"n":     nil,
+		"age":   ptrString("10"),
+		"born":  ptrString("2022-01-02"),
+		"at":    ptrString("2022-01-02 10:00:00+03"),
+		"admin": ptrString("yes"),
+		"bad":   ptrString("unknown"),
+	}
+
+	tests := []struct {
+		pred    *Predicate
+		input   Hstore
+		want    bool
+		wantErr bool
+	}{
+		{pred: Matchable.HasKey("c", "a"), input: row, want: true},
+		{pred: Matchable.HasKey("c", "n"), input: row, want: true},
+		{pred: Matchable.HasKey("c", "x"), input: row, want: false},
+		{pred: Matchable.HasKey("c", "a"), input: nil, want: false},
+		{pred: Matchable.NotHasKey("c", "x"), input: row, want: true},
+		{pred: Matchable.NotHasKey("c", "x"), input: nil, want: false},
+		{pred: Matchable.HasAllKeys("c", "a", "n"), input: row, want: true},
+		{pred: Matchable.HasAllKeys("c", "a", "x"), input: row, want: false},
+		{pred: Matchable.HasAnyKeys("c", "a", "x"), input: row, want: true},
+		{pred: Matchable.HasAnyKeys("c", "x", "y"), input: row, want: false},
+		{pred: Matchable.Contains("c", Hstore{"a": ptrString("b"), "n": nil}), input: row, want: true},
+		{pred: Matchable.Contains("c", Hstore{"a": ptrString("c")}), input: row, want: false},
+		{pred: Matchable.Contains("c", Hstore{"n": ptrString("")}), input: row, want: false},
+		{pred: Matchable.Contains("c", Hstore{}), input: Hstore{}, want: true},
+		{pred: Matchable.NotContains("c", Hstore{"a": ptrString("c")}), input: row, want: true},
+		{pred: Matchable.ContainedBy("c", row), input: Hstore{"a": ptrString("b")}, want: true},
+		{pred: Matchable.ContainedBy("c", Hstore{"a": ptrString("b")}), input: row, want: false},
+		{pred: Matchable.NotContainedBy("c", Hstore{"a": ptrString("b")}), input: row, want: true},
+		{pred: Matchable.ValueIsNull("c", "n"), input: row, want: true},
+		{pred: Matchable.ValueIsNull("c", "x"), input: row, want: true},
+		{pred: Matchable.ValueIsNull("c", "a"), input: row, want: false},
+		{pred: Matchable.ValueIsNull("c", "a"), input: nil, want: false},
+		{pred: Matchable.Not(Matchable.ValueIsNull("c", "a")), input: nil, want: true},
+		{pred: Matchable.ValueEQ("c", "a", "b"), input: row, want: true},
+		{pred: Matchable.ValueNEQ("c", "a", "b"), input: row, want: false},
+		{pred: Matchable.ValueNEQ("c", "n", "b"), input: row, want: false},
+		{pred: Matchable.ValueNEQ("c", "x", "b"), input: row, want: false},
+		{pred: Matchable.ValueGT("c", "age", "9"), input: row, want: false},
+		{pred: Matchable.ValueGTE("c", "age", "10"), input: row, want: true},
+		{pred: Matchable.ValueLT("c", "age", "9"), input: row, want: true},
+		{pred: Matchable.ValueLTE("c", "age", "1"), input: row, want: false},
+		{pred: Matchable.ValueContains("c", "born", "-01-"), input: row, want: true},
+		{pred: Matchable.ValueContains("c", "born", "%"), input: row, want: false},
+		{pred: Matchable.ValueHasPrefix("c", "born", "2022"), input: row, want: true},
+		{pred: Matchable.ValueHasSuffix("c", "born", "2022"), input: row, want: false},
+		{pred: Matchable.ValueIn("c", "a", "x", "b"), input: row, want: true},
+		{pred: Matchable.ValueIn("c", "a", "x"), input: row, want: false},
+		{pred: Matchable.ValueIn("c", "n", "x"), input: row, want: false},
+		{pred: Matchable.ValueIn("c", "a"), input: row, want: false},
+		{pred: Matchable.ValueNotIn("c", "a", "x"), input: row, want: true},
+		{pred: Matchable.ValueNotIn("c", "a", "b"), input: row, want: false},
+		{pred: Matchable.ValueNotIn("c", "n", "x"), input: row, want: false},
+		{pred: Matchable.ValueNotIn("c", "x", "b"), input: nil, want: false},
+		{pred: Matchable.ValueNotIn("c", "n"), input: row, want: true},
+		{pred: Matchable.Not(Matchable.ValueIn("c", "n")), input: nil, want: true},
+		{pred: Matchable.AnyValueEQ("c", "10"), input: row, want: true},
+		{pred: Matchable.AnyValueEQ("c", "x"), input: row, want: false},
+		{pred: Matchable.Not(Matchable.AnyValueEQ("c", "x")), input: nil, want: false},
+		{pred: Matchable.AnyValueContains("c", "-01-"), input: row, want: true},
+		{pred: Matchable.AnyValueContains("c", "%"), input: row, want: false},
+		{pred: Matchable.Not(Matchable.AnyValueContains("c", "x")), input: nil, want: true},
+		{pred: Matchable.KeyMatches("c", "^b[a-z]+$"), input: row, want: true},
+		{pred: Matchable.KeyMatches("c", "^x"), input: row, want: false},
+		{pred: Matchable.KeyMatches("c", "("), input: row, wantErr: true},
+		{pred: Matchable.KeyMatches("c", "("), input: Hstore{}, wantErr: true},
+		{pred: Matchable.IsEmpty("c"), input: Hstore{}, want: true},
+		{pred: Matchable.IsEmpty("c"), input: row, want: false},
+		{pred: Matchable.IsEmpty("c"), input: nil, want: false},
+		{pred: Matchable.Not(Matchable.IsEmpty("c")), input: nil, want: false},
+		{pred: Matchable.IsNotEmpty("c"), input: row, want: true},
+		{pred: Matchable.IsNotEmpty("c"), input: nil, want: false},
+		{pred: Matchable.KeyCountEQ("c", 7), input: row, want: true},
+		{pred: Matchable.KeyCountNEQ("c", 7), input: row, want: false},
+		{pred: Matchable.KeyCountGT("c", 6), input: row, want: true},
+		{pred: Matchable.KeyCountGTE("c", 8), input: row, want: false},
+		{pred: Matchable.KeyCountLT("c", 1), input: Hstore{}, want: true},
+		{pred: Matchable.KeyCountLTE("c", 0), input: nil, want: false},
+		{pred: Matchable.ValueEqualFold("c", "admin", "YES"), input: row, want: true},
+		{pred: Matchable.ValueEqualFold("c", "admin", "Y%"), input: row, want: false},
+		{pred: Matchable.ValueContainsFold("c", "bad", "KNO"), input: row, want: true},
+		{pred: Matchable.ValueLike("c", "born", "2022-__-%"), input: row, want: true},
+		{pred: Matchable.ValueLike("c", "born", `2022\-%`), input: row, want: true},
+		{pred: Matchable.ValueLike("c", "born", "2022"), input: row, want: false},
+		{pred: Matchable.ValueLike("c", "born", `2022\`), input: row, wantErr: true},
+		{pred: Matchable.ValueNotLike("c", "born", "2021%"), input: row, want: true},
+		{pred: Matchable.ValueNotLike("c", "x", "2021%"), input: row, want: false},
+		{pred: Matchable.ValueRegex("c", "born", `^\d{4}-01`), input: row, want: true},
+		{pred: Matchable.ValueRegex("c", "admin", "^Y"), input: row, want: false},
+		{pred: Matchable.ValueRegexFold("c", "admin", "^Y"), input: row, want: true},
+		{pred: Matchable.ValueRegex("c", "admin", "("), input: row, wantErr: true},
+		{pred: Matchable.ValueSimilarTo("c", "admin", "(yes|no)"), input: row, want: true},
+		{pred: Matchable.ValueSimilarTo("c", "born", "2022-%"), input: row, want: true},
+		{pred: Matchable.ValueSimilarTo("c", "born", "2022.%"), input: row, want: false},
+		{pred: Matchable.ValueSimilarTo("c", "at", "[0-9]{4}-__-__ %"), input: row, want: true},
+		{pred: Matchable.ValueSimilarTo("c", "at", "2022"), input: row, want: false},
+		{pred: Matchable.Value("c", "age").As(Numeric).GT(9), input: row, want: true},
+		{pred: Matchable.Value("c", "age").As(Numeric).GT(9.5), input: row, want: true},
+		{pred: Matchable.Value("c", "age").As(Integer).EQ("10"), input: row, want: true},
+		{pred: Matchable.Value("c", "age").As(Integer).Between(1, 10), input: row, want: true},
+		{pred: Matchable.Value("c", "age").As(Integer).Between(11, 20), input: row, want: false},
+		{pred: Matchable.Value("c", "born").As(Date).LT(time.Date(2022, 1, 3, 0, 0, 0, 0, time.UTC)), input: row, want: true},
+		{pred: Matchable.Value("c", "born").As(Date).EQ("2022-01-02"), input: row, want: true},
+		{pred: Matchable.Value("c", "at").As(Timestamp).EQ(time.Date(2022, 1, 2, 7, 0, 0, 0, time.UTC)), input: row, want: true},
+		{pred: Matchable.Value("c", "at").As(Timestamp).GT("2022-01-02T08:00:00Z"), input: row, want: false},
+		{pred: Matchable.Value("c", "admin").As(Boolean).EQ(true), input: row, want: true},
+		{pred: Matchable.Value("c", "admin").As(Boolean).GT(false), input: row, want: true},
+		{pred: Matchable.Value("c", "bad").As(Numeric).GT(1), input: row, wantErr: true},
+		{pred: Matchable.Value("c", "bad").As(Numeric).Guard().GT(1), input: row, want: false},
+		{pred: Matchable.Not(Matchable.Value("c", "bad").As(Numeric).Guard().GT(1)), input: row, want: false},
+		{pred: Matchable.Value("c", "x").As(Numeric).GT(1), input: row, want: false},
+		{pred: Matchable.And(Matchable.HasKey("c", "a"), Matchable.ValueEQ("c", "a", "b")), input: row, want: true},
+		{pred: Matchable.And(Matchable.HasKey("c", "a"), Matchable.ValueEQ("c", "a", "c")), input: row, want: false},
+		{pred: Matchable.Or(Matchable.ValueEQ("c", "x", "b"), Matchable.ValueEQ("c", "a", "b")), input: row, want: true},
+		{pred: Matchable.Not(Matchable.Or(Matchable.ValueEQ("c", "x", "b"), Matchable.ValueEQ("c", "a", "c"))), input: row, want: false},
+		{pred: Matchable.Not(Matchable.And(Matchable.ValueEQ("c", "x", "b"), Matchable.ValueEQ("c", "a", "c"))), input: row, want: true},
+		{pred: Matchable.And(Matchable.HasKey("c", "a"), &Predicate{Predicate: sql.EQ("c", 1)}), input: row, wantErr: true},
+	}
+	for i, tt := range tests {
+		tt := tt
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+			got, err := Match(tt.pred, tt.input)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMatch_unsupported(t *testing.T) {
+	t.Parallel()
+
+	_, err := Match(&Predicate{Predicate: sql.EQ("c", 1)}, Hstore{})
+	require.ErrorIs(t, err, ErrUnsupportedPredicate)
+
+	_, err = Match(Matchable.Not(&Predicate{Predicate: sql.Not(Matchable.HasKey("c", "a").Predicate)}), Hstore{})
+	require.ErrorIs(t, err, ErrUnsupportedPredicate)
+
+	_, err = Match(nil, Hstore{})
+	require.ErrorIs(t, err, ErrUnsupportedPredicate)
+}
+
+func ptrString(s string) *string {
+	return &s
+}
@@ -0,0 +1,197 @@
+package enthstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/postgres"
+	atlas "ariga.io/atlas/sql/schema"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql/schema"
+)
+
+// ErrInsufficientPrivilege is the error returned by the migration when
+// the database user is not allowed to create the hstore extension.
+var ErrInsufficientPrivilege = errors.New("hstore: insufficient privilege to create the hstore extension, " +
+	"create it with a superuser or grant the CREATE privilege on the database")
+
+// insufficientPrivilege is the SQLSTATE of the insufficient privilege errors.
+const insufficientPrivilege = "42501"
+
+// ExtensionOption configures the EnableHstoreOption and ExtensionFormatter.
+type ExtensionOption func(*extensionOptions)
+
+type extensionOptions struct {
+	schema string
+}
+
+// ExtensionSchema defines the schema the extension is installed, the default
+// is "public". An empty schema installs the extension on the current schema.
+func ExtensionSchema(name string) ExtensionOption {
+	return func(o *extensionOptions) {
+		o.schema = name
+	}
+}
+
+// EnableHstoreOption returns a schema.MigrateOption that creates the
+// hstore extension when the migration adds a hstore column. The statement
+// is executed by Schema.Create, it's idempotent and the migrations without
+// hstore columns are not changed. The versioned migration files written by
+// Schema.Diff are changed by the ExtensionFormatter.
+//
+//	err := client.Schema.Create(ctx, enthstore.EnableHstoreOption())
+func EnableHstoreOption(opts ...ExtensionOption) schema.MigrateOption {
+	change := extensionChange(opts)
+	return schema.WithApplyHook(func(next schema.Applier) schema.Applier {
+		return extensionApplier(change, next)
+	})
+}
+
+// ExtensionFormatter wraps the formatter of the versioned migration files,
+// adding the statement that creates the hstore extension to the files that
+// add a hstore column. The formatter used by ent when none is configured is
+// sqltool.GolangMigrateFormatter.
+//
+//	err := client.Schema.Diff(ctx,
+//		schema.WithDir(dir),
+//		schema.WithFormatter(enthstore.ExtensionFormatter(sqltool.GolangMigrateFormatter)),
+//	)
+func ExtensionFormatter(f migrate.Formatter, opts ...ExtensionOption) migrate.Formatter {
+	return extensionFormatter(extensionChange(opts), f)
+}
+
+// extensionChange returns the change that creates the extension.
+func extensionChange(opts []ExtensionOption) *migrate.Change {
+	o := extensionOptions{schema: "public"}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &migrate.Change{
+		Cmd:     createExtension(o.schema),
+		Comment: "Enable the hstore extension",
+	}
+}
+
+// extensionApplier executes the change before the plans using hstore.
+func extensionApplier(change *migrate.Change, next schema.Applier) schema.Applier {
+	return schema.ApplyFunc(func(ctx context.Context, conn dialect.ExecQuerier, plan *migrate.Plan) error {
+		if usesHstore(plan) {
+			if err := conn.Exec(ctx, change.Cmd, []interface{}{}, nil); err != nil {
+				return extensionError(err)
+			}
+		}
+
+		return next.Apply(ctx, conn, plan)
+	})
+}
+
+// extensionFormatter adds the change to the plans using hstore.
+func extensionFormatter(change *migrate.Change, next migrate.Formatter) migrate.Formatter {
+	return formatterFunc(func(plan *migrate.Plan) ([]migrate.File, error) {
+		if usesHstore(plan) {
+			p := *plan
+			p.Changes = append([]*migrate.Change{change}, plan.Changes...)
+			plan = &p
+		}
+
+		return next.Format(plan)
+	})
+}
+
+// createExtension returns the statement that creates the extension.
+func createExtension(name string) string {
+	if name == "" {
+		return "CREATE EXTENSION IF NOT EXISTS hstore"
+	}
+
+	return `CREATE EXTENSION IF NOT EXISTS hstore WITH SCHEMA "` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// formatterFunc adapts a function to the migrate.Formatter interface.
+type formatterFunc func(*migrate.Plan) ([]migrate.File, error)
+
+// Format calls f(plan).
+func (f formatterFunc) Format(plan *migrate.Plan) ([]migrate.File, error) {
+	return f(plan)
+}
+
+// usesHstore reports whether the plan adds a hstore column
+// or changes the type of a column to hstore.
+func usesHstore(plan *migrate.Plan) bool {
+	for _, c := range plan.Changes {
+		switch c := c.Source.(type) {
+		case *atlas.AddTable:
+			for _, col := range c.T.Columns {
+				if isHstoreColumn(col) {
+					return true
+				}
+			}
+		case *atlas.ModifyTable:
+			for _, change := range c.Changes {
+				switch change := change.(type) {
+				case *atlas.AddColumn:
+					if isHstoreColumn(change.C) {
+						return true
+					}
+				case *atlas.ModifyColumn:
+					if isHstoreColumn(change.To) && !isHstoreColumn(change.From) {
+						return true
+					}
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+func isHstoreColumn(c *atlas.Column) bool {
+	if c == nil || c.Type == nil {
+		return false
+	}
+
+	switch t := c.Type.Type.(type) {
+	case *postgres.UserDefinedType:
+		return strings.EqualFold(t.T, "hstore")
+	case *atlas.UnsupportedType:
+		return strings.EqualFold(t.T, "hstore")
+	}
+
+	return strings.EqualFold(c.Type.Raw, "hstore")
+}
+
+// extensionError wraps the insufficient privilege errors with ErrInsufficientPrivilege.
+func extensionError(err error) error {
+	if sqlState(err) == insufficientPrivilege {
+		return fmt.Errorf("%w: %v", ErrInsufficientPrivilege, err)
+	}
+
+	return fmt.Errorf("could not enable hstore extension: %w", err)
+}
+
+// sqlState returns the SQLSTATE of the error, the pgx errors implement
+// the SQLState method and the lib/pq errors have the Code field.
+func sqlState(err error) string {
+	var state interface{ SQLState() string }
+	if errors.As(err, &state) {
+		return state.SQLState()
+	}
+
+	for ; err != nil; err = errors.Unwrap(err) {
+		v := reflect.Indirect(reflect.ValueOf(err))
+		if v.Kind() != reflect.Struct {
+			continue
+		}
+
+		if code := v.FieldByName("Code"); code.IsValid() && code.Kind() == reflect.String {
+			return code.String()
+		}
+	}
+
+	return ""
+}
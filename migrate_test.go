@@ -0,0 +1,152 @@
+package enthstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/postgres"
+	atlas "ariga.io/atlas/sql/schema"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateExtension(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, `CREATE EXTENSION IF NOT EXISTS hstore WITH SCHEMA "public"`, createExtension("public"))
+	require.Equal(t, `CREATE EXTENSION IF NOT EXISTS hstore WITH SCHEMA "my""schema"`, createExtension(`my"schema`))
+	require.Equal(t, `CREATE EXTENSION IF NOT EXISTS hstore`, createExtension(""))
+}
+
+func hstoreColumn() *atlas.Column {
+	return atlas.NewColumn("attributes").SetType(&postgres.UserDefinedType{T: "hstore"})
+}
+
+func TestUsesHstore(t *testing.T) {
+	t.Parallel()
+
+	textColumn := atlas.NewStringColumn("name", "text")
+	tests := []struct {
+		source atlas.Change
+		want   bool
+	}{
+		{source: nil, want: false},
+		{source: &atlas.AddTable{T: atlas.NewTable("users").AddColumns(textColumn)}, want: false},
+		{source: &atlas.AddTable{T: atlas.NewTable("users").AddColumns(textColumn, hstoreColumn())}, want: true},
+		{source: &atlas.ModifyTable{Changes: []atlas.Change{&atlas.AddColumn{C: hstoreColumn()}}}, want: true},
+		{
+			source: &atlas.ModifyTable{Changes: []atlas.Change{
+				&atlas.AddColumn{C: atlas.NewColumn("other").SetType(&atlas.UnsupportedType{T: "HSTORE"})},
+			}},
+			want: true,
+		},
+		{
+			source: &atlas.ModifyTable{Changes: []atlas.Change{&atlas.ModifyColumn{From: textColumn, To: hstoreColumn()}}},
+			want:   true,
+		},
+		{
+			source: &atlas.ModifyTable{Changes: []atlas.Change{&atlas.ModifyColumn{From: hstoreColumn(), To: hstoreColumn()}}},
+			want:   false,
+		},
+		{source: &atlas.DropTable{T: atlas.NewTable("users").AddColumns(hstoreColumn())}, want: false},
+	}
+	for i, tt := range tests {
+		tt := tt
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+			plan := &migrate.Plan{Changes: []*migrate.Change{{Cmd: "", Source: tt.source}}}
+			require.Equal(t, tt.want, usesHstore(plan))
+		})
+	}
+}
+
+func TestExtensionFormatter(t *testing.T) {
+	t.Parallel()
+
+	f := ExtensionFormatter(migrate.DefaultFormatter)
+
+	files, err := f.Format(&migrate.Plan{Name: "users", Changes: []*migrate.Change{
+		{Cmd: `CREATE TABLE "users" ("attributes" hstore NOT NULL)`, Source: &atlas.AddTable{
+			T: atlas.NewTable("users").AddColumns(hstoreColumn()),
+		}},
+	}})
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	require.Equal(t, "-- Enable the hstore extension\n"+
+		"CREATE EXTENSION IF NOT EXISTS hstore WITH SCHEMA \"public\";\n"+
+		"CREATE TABLE \"users\" (\"attributes\" hstore NOT NULL);\n", string(files[0].Bytes()))
+
+	files, err = f.Format(&migrate.Plan{Name: "other", Changes: []*migrate.Change{
+		{Cmd: `DROP TABLE "other"`, Source: &atlas.DropTable{T: atlas.NewTable("other")}},
+	}})
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	require.Equal(t, "DROP TABLE \"other\";\n", string(files[0].Bytes()))
+}
+
+// fakeExecQuerier records the statements executed.
+type fakeExecQuerier struct {
+	dialect.ExecQuerier
+	statements []string
+	err        error
+}
+
+func (e *fakeExecQuerier) Exec(_ context.Context, query string, _, _ interface{}) error {
+	e.statements = append(e.statements, query)
+	return e.err
+}
+
+// stateError is an error with the SQLState method, like the pgx errors.
+type stateError struct{ code string }
+
+func (e *stateError) Error() string    { return "state " + e.code }
+func (e *stateError) SQLState() string { return e.code }
+
+// codeError is an error with the Code field, like the lib/pq errors.
+type codeError struct{ Code string }
+
+func (e *codeError) Error() string { return "code " + e.Code }
+
+func TestExtensionApplier(t *testing.T) {
+	t.Parallel()
+
+	change := &migrate.Change{Cmd: createExtension("public")}
+	applier := extensionApplier(change, schema.ApplyFunc(func(ctx context.Context, conn dialect.ExecQuerier, plan *migrate.Plan) error {
+		for _, c := range plan.Changes {
+			if err := conn.Exec(ctx, c.Cmd, []interface{}{}, nil); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}))
+
+	hstorePlan := &migrate.Plan{Changes: []*migrate.Change{
+		{Cmd: "CREATE TABLE", Source: &atlas.AddTable{T: atlas.NewTable("users").AddColumns(hstoreColumn())}},
+	}}
+
+	conn := &fakeExecQuerier{}
+	require.NoError(t, applier.Apply(context.Background(), conn, hstorePlan))
+	require.Equal(t, []string{change.Cmd, "CREATE TABLE"}, conn.statements)
+
+	conn = &fakeExecQuerier{}
+	require.NoError(t, applier.Apply(context.Background(), conn, &migrate.Plan{}))
+	require.Empty(t, conn.statements)
+
+	for _, err := range []error{&stateError{code: "42501"}, fmt.Errorf("wrapped: %w", &codeError{Code: "42501"})} {
+		conn = &fakeExecQuerier{err: err}
+		err := applier.Apply(context.Background(), conn, hstorePlan)
+		require.ErrorIs(t, err, ErrInsufficientPrivilege)
+		require.Len(t, conn.statements, 1)
+	}
+
+	conn = &fakeExecQuerier{err: errors.New("connection closed")}
+	err := applier.Apply(context.Background(), conn, hstorePlan)
+	require.EqualError(t, err, "could not enable hstore extension: connection closed")
+	require.NotErrorIs(t, err, ErrInsufficientPrivilege)
+}
@@ -0,0 +1,92 @@
+package enthstore
+
+import (
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+)
+
+// OrderOption configures the ordering of OrderByValue.
+type OrderOption func(*orderOptions)
+
+type orderOptions struct {
+	desc  bool
+	nulls string
+	typ   *ValueType
+	guard bool
+}
+
+// OrderDesc sorts the values in descending order, the default is ascending.
+func OrderDesc() OrderOption {
+	return func(o *orderOptions) {
+		o.desc = true
+	}
+}
+
+// OrderNullsFirst sorts the NULL values and the missing keys first.
+func OrderNullsFirst() OrderOption {
+	return func(o *orderOptions) {
+		o.nulls = "NULLS FIRST"
+	}
+}
+
+// OrderNullsLast sorts the NULL values and the missing keys last.
+func OrderNullsLast() OrderOption {
+	return func(o *orderOptions) {
+		o.nulls = "NULLS LAST"
+	}
+}
+
+// OrderAs casts the values to the type before sorting, like CastValue,
+// a guarded cast sorts the values that can't be cast as NULL.
+func OrderAs(t ValueType, guard bool) OrderOption {
+	return func(o *orderOptions) {
+		o.typ = &t
+		o.guard = guard
+	}
+}
+
+// OrderByValue returns an order function that sorts by the value of the key,
+// the values are sorted as text unless they are cast with OrderAs. It's only
+// supported by Postgres and can be used with the Order method of the ent queries:
+//
+//	client.User.Query().
+//		Order(enthstore.OrderByValue(user.FieldAttributes, "priority",
+//			enthstore.OrderAs(enthstore.Numeric, true),
+//			enthstore.OrderDesc(),
+//		)).
+//		All(ctx)
+func OrderByValue(column string, key string, opts ...OrderOption) func(*sql.Selector) {
+	o := &orderOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return func(s *sql.Selector) {
+		s.OrderExpr(sql.ExprFunc(func(b *sql.Builder) {
+			if isJSONDialect(b.Dialect()) {
+				b.AddError(fmt.Errorf("hstore: OrderByValue is not supported by %s", b.Dialect()))
+				return
+			}
+
+			v := Value(s.C(column), key)
+			if o.typ != nil {
+				c := v.As(*o.typ)
+				c.guard = o.guard
+				c.writeTo(b)
+			} else {
+				b.WriteString("(")
+				v.writeTo(b)
+				b.WriteString(")")
+			}
+
+			if o.desc {
+				b.WriteString(" DESC")
+			}
+
+			if o.nulls != "" {
+				b.WriteString(" ").WriteString(o.nulls)
+			}
+		}))
+	}
+}
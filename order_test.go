@@ -0,0 +1,66 @@
+package enthstore
+
+import (
+	"strconv"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderByValue(t *testing.T) {
+	t.Parallel()
+
+	query := func(order func(*sql.Selector)) (string, []interface{}) {
+		users := sql.Table("users")
+		s := sql.Dialect(dialect.Postgres).Select(users.C("id")).From(users)
+		order(s)
+
+		return s.Query()
+	}
+
+	tests := []struct {
+		input     func(*sql.Selector)
+		wantQuery string
+	}{
+		{
+			input:     OrderByValue("attributes", "it's"),
+			wantQuery: `SELECT "users"."id" FROM "users" ORDER BY ("users"."attributes" -> 'it''s')`,
+		},
+		{
+			input:     OrderByValue("attributes", "priority", OrderDesc(), OrderNullsLast()),
+			wantQuery: `SELECT "users"."id" FROM "users" ORDER BY ("users"."attributes" -> 'priority') DESC NULLS LAST`,
+		},
+		{
+			input:     OrderByValue("attributes", "priority", OrderAs(Numeric, false), OrderNullsFirst()),
+			wantQuery: `SELECT "users"."id" FROM "users" ORDER BY ("users"."attributes" -> 'priority')::numeric NULLS FIRST`,
+		},
+		{
+			input: OrderByValue("attributes", "at", OrderAs(Timestamp, true), OrderDesc()),
+			wantQuery: `SELECT "users"."id" FROM "users" ORDER BY CASE WHEN "users"."attributes" -> 'at' ~* '` + Timestamp.pattern +
+				`' THEN ("users"."attributes" -> 'at')::timestamptz END DESC`,
+		},
+	}
+	for i, tt := range tests {
+		tt := tt
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+			query, args := query(tt.input)
+			require.Equal(t, tt.wantQuery, query)
+			require.Empty(t, args)
+		})
+	}
+}
+
+func TestOrderByValueJSON(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{dialect.SQLite, dialect.MySQL} {
+		users := sql.Table("users")
+		s := sql.Dialect(name).Select(users.C("id")).From(users)
+		OrderByValue("attributes", "priority", OrderNullsLast())(s)
+		s.Query()
+		require.EqualError(t, s.Err(), "hstore: OrderByValue is not supported by "+name)
+	}
+}
@@ -0,0 +1,445 @@
+package enthstore
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ParseError is the error returned when parsing a malformed
+// hstore text representation in strict mode.
+type ParseError struct {
+	// Offset is the byte offset of the offending character.
+	Offset int
+	// Char is the offending character, or zero when
+	// the end of the input was reached unexpectedly.
+	Char byte
+	// Expected describes the token that was expected.
+	Expected string
+}
+
+// Error implements the interface error.
+func (e *ParseError) Error() string {
+	if e.Char == 0 {
+		return fmt.Sprintf("hstore: unexpected end of input at offset %d, expected %s", e.Offset, e.Expected)
+	}
+
+	return fmt.Sprintf("hstore: unexpected %q at offset %d, expected %s", e.Char, e.Offset, e.Expected)
+}
+
+// ParseStrict parses the text representation of an hstore, returning
+// a *ParseError when the input is malformed, like unbalanced quotes,
+// a dangling escape, a missing "=>" or extra tokens on a pair.
+func ParseStrict(input string) (Hstore, error) {
+	hs := make(Hstore, countPairs(input))
+
+	p := parser{input: input, strict: true}
+	if err := p.parse(hs); err != nil {
+		return nil, err
+	}
+
+	return hs, nil
+}
+
+// ScanStrict works like Scan, but reports malformed input
+// with a *ParseError instead of ignoring it.
+func (h *Hstore) ScanStrict(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	input, err := scanInput(value)
+	if err != nil {
+		return err
+	}
+
+	if isJSONObject(input) {
+		return h.scanJSON(input)
+	}
+
+	parsed, err := ParseStrict(input)
+	if err != nil {
+		return err
+	}
+
+	if *h == nil {
+		*h = parsed
+		return nil
+	}
+
+	for k, v := range parsed {
+		(*h)[k] = v
+	}
+
+	return nil
+}
+
+// scanInput returns the text representation from a value
+// provided by the driver. The driver may reuse a []byte
+// after Scan returns, so it is copied once here and every
+// key and value is sliced from the copy.
+func scanInput(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	default:
+		return "", fmt.Errorf("invalid input type: %T", v)
+	}
+}
+
+// isJSONObject reports whether the input is a JSON object stored by
+// SQLite or MySQL. Postgres quotes every key of the text representation
+// of an hstore, so the values read from Postgres never start with "{",
+// the unquoted keys starting with "{", like "{a}=>b", aren't valid JSON.
+func isJSONObject(input string) bool {
+	return strings.HasPrefix(strings.TrimLeft(input, " \t\n\r"), "{") && json.Valid([]byte(input))
+}
+
+// scanJSON decodes a JSON object into the Hstore, the values
+// must be strings or null, like the values of an hstore.
+func (h *Hstore) scanJSON(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	input, err := scanInput(value)
+	if err != nil {
+		return err
+	}
+
+	var parsed Hstore
+	if err := json.Unmarshal([]byte(input), &parsed); err != nil {
+		return fmt.Errorf("hstore: invalid JSON object: %w", err)
+	}
+
+	if *h == nil {
+		*h = make(Hstore, len(parsed))
+	}
+
+	for k, v := range parsed {
+		(*h)[k] = v
+	}
+
+	return nil
+}
+
+// countPairs returns an upper bound of the number of pairs,
+// since every pair has one "=>" outside of its key and value.
+func countPairs(input string) int {
+	return strings.Count(input, "=>")
+}
+
+type parseState int
+
+const (
+	stateKey parseState = iota
+	stateEq
+	stateGt
+	stateValue
+	stateDelim
+)
+
+// parser is a single-pass state machine over the text representation
+// of an hstore. Keys and values without escapes are sliced directly
+// from the input, and values share a single backing array, so parsing
+// a pair does not allocate besides the map insertion.
+//
+// In strict mode the first syntax error is returned as a *ParseError,
+// otherwise the input is parsed again by parseLenient, which ignores
+// the syntax errors.
+type parser struct {
+	input   string
+	pos     int
+	strict  bool
+	scratch []byte
+	values  []string
+}
+
+func (p *parser) parse(h Hstore) error {
+	var (
+		state = stateKey
+		key   string
+	)
+
+	p.values = make([]string, 0, countPairs(p.input))
+
+	for {
+		// "=>" is a single token, so no whitespace is allowed before ">".
+		if state != stateGt {
+			p.skipSpaces()
+		}
+
+		switch state {
+		case stateKey:
+			if p.eof() {
+				return nil
+			}
+
+			k, _, err := p.token("key")
+			if err != nil {
+				return p.fail(h, err)
+			}
+
+			key = k
+			state = stateEq
+		case stateEq:
+			if err := p.expect('=', `"=>"`); err != nil {
+				return p.fail(h, err)
+			}
+
+			state = stateGt
+		case stateGt:
+			if err := p.expect('>', `"=>"`); err != nil {
+				return p.fail(h, err)
+			}
+
+			state = stateValue
+		case stateValue:
+			val, quoted, err := p.token("value")
+			if err != nil {
+				return p.fail(h, err)
+			}
+
+			if !quoted && len(val) == 4 && strings.EqualFold(val, "NULL") {
+				h[key] = nil
+			} else {
+				h[key] = p.store(val)
+			}
+
+			state = stateDelim
+		case stateDelim:
+			if p.eof() {
+				return nil
+			}
+
+			if err := p.expect(',', `"," or end of input`); err != nil {
+				return p.fail(h, err)
+			}
+
+			state = stateKey
+		}
+	}
+}
+
+// store returns a pointer to val backed by the shared values array.
+func (p *parser) store(val string) *string {
+	if len(p.values) == cap(p.values) {
+		// pointers to the current array were already handed
+		// out, so a new one is started instead of growing it.
+		p.values = make([]string, 0, 8)
+	}
+
+	p.values = append(p.values, val)
+	return &p.values[len(p.values)-1]
+}
+
+// token reads a quoted or unquoted token, reporting
+// if the token was quoted.
+func (p *parser) token(name string) (string, bool, error) {
+	if p.eof() {
+		return "", false, p.errorAt(name)
+	}
+
+	if p.input[p.pos] == '"' {
+		p.pos++
+		s, err := p.quoted()
+		return s, true, err
+	}
+
+	start := p.pos
+	escaped := false
+
+	for !p.eof() {
+		c := p.input[p.pos]
+
+		if isSpace(c) || isDelimiter(c) {
+			break
+		}
+
+		if c == '\\' {
+			escaped = true
+			p.pos++
+			if p.eof() {
+				return "", false, p.errorAt("escaped character")
+			}
+		}
+
+		p.pos++
+	}
+
+	if p.pos == start {
+		return "", false, p.errorAt(name)
+	}
+
+	if escaped {
+		return p.unescape(p.input[start:p.pos]), false, nil
+	}
+
+	return p.input[start:p.pos], false, nil
+}
+
+func (p *parser) quoted() (string, error) {
+	start := p.pos
+	escaped := false
+
+	for !p.eof() {
+		switch p.input[p.pos] {
+		case '"':
+			s := p.input[start:p.pos]
+			p.pos++
+
+			if escaped {
+				return p.unescape(s), nil
+			}
+
+			return s, nil
+		case '\\':
+			escaped = true
+			p.pos++
+			if p.eof() {
+				return "", p.errorAt("escaped character")
+			}
+		}
+
+		p.pos++
+	}
+
+	return "", p.errorAt(`closing '"'`)
+}
+
+// unescape removes the backslashes from s, the escapes
+// were already validated when the token was read.
+func (p *parser) unescape(s string) string {
+	p.scratch = p.scratch[:0]
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' {
+			i++
+		}
+
+		p.scratch = append(p.scratch, s[i])
+	}
+
+	return string(p.scratch)
+}
+
+func (p *parser) expect(c byte, expected string) error {
+	if p.eof() || p.input[p.pos] != c {
+		return p.errorAt(expected)
+	}
+
+	p.pos++
+	return nil
+}
+
+// fail returns err in strict mode, otherwise the error is ignored
+// and the whole input is parsed by parseLenient into h.
+func (p *parser) fail(h Hstore, err error) error {
+	if p.strict {
+		return err
+	}
+
+	parseLenient(p.input, h)
+	return nil
+}
+
+// parseLenient parses the input ignoring the syntax errors, like the
+// original Scan: the "=" and the whitespace outside of quotes are ignored,
+// a ">" starts the value, a "," ends the pair and an unbalanced quote
+// extends to the end of the input. A key without a value has an empty
+// value, so "a=>b, c" has the keys "a" and "c".
+//
+// The well-formed pairs are parsed like the parser does, so the pairs
+// read by the parser before the syntax error are set to the same values.
+func parseLenient(input string, h Hstore) {
+	var (
+		record      [2][]byte
+		cur         int
+		escaping    bool
+		insideQuote bool
+		lastQuoted  bool
+	)
+
+	savePair := func() {
+		key, value := string(record[0]), string(record[1])
+		if !lastQuoted && strings.EqualFold(value, "NULL") {
+			h[key] = nil
+		} else {
+			h[key] = &value
+		}
+
+		record[0], record[1] = record[0][:0], record[1][:0]
+		cur = 0
+	}
+
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return
+	}
+
+	for i := 0; i < len(input); i++ {
+		c := input[i]
+
+		switch {
+		case escaping:
+			escaping = false
+			record[cur] = append(record[cur], c)
+		case c == '\\':
+			escaping = true
+		case c == '"':
+			insideQuote = !insideQuote
+			if !insideQuote {
+				lastQuoted = true
+			}
+		case insideQuote:
+			record[cur] = append(record[cur], c)
+		case c == '=' || isSpace(c):
+		case c == '>':
+			// the original Scan failed on a second ">",
+			// it's now part of the value.
+			if cur == 1 {
+				record[cur] = append(record[cur], c)
+				continue
+			}
+
+			cur = 1
+			lastQuoted = false
+		case c == ',':
+			savePair()
+		default:
+			record[cur] = append(record[cur], c)
+		}
+	}
+
+	savePair()
+}
+
+func (p *parser) skipSpaces() {
+	for !p.eof() && isSpace(p.input[p.pos]) {
+		p.pos++
+	}
+}
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *parser) errorAt(expected string) error {
+	err := &ParseError{Offset: p.pos, Expected: expected}
+	if !p.eof() {
+		err.Char = p.input[p.pos]
+	}
+
+	return err
+}
+
+// isDelimiter reports whether c must be quoted or escaped
+// when part of a key or value.
+func isDelimiter(c byte) bool {
+	return c == '"' || c == '=' || c == '>' || c == ','
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\v' || c == '\f'
+}
@@ -0,0 +1,109 @@
+package enthstore
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStrict(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		args       string
+		wantHstore Hstore
+	}{
+		{args: "", wantHstore: Hstore{}},
+		{args: "  ", wantHstore: Hstore{}},
+		{args: "a=>b", wantHstore: FromMap(map[string]string{"a": "b"})},
+		{args: " a => b ", wantHstore: FromMap(map[string]string{"a": "b"})},
+		{args: `"a"=>"b"`, wantHstore: FromMap(map[string]string{"a": "b"})},
+		{args: `aa=>bb, "cc"=>dd`, wantHstore: FromMap(map[string]string{"aa": "bb", "cc": "dd"})},
+		{args: `aa=>bb,`, wantHstore: FromMap(map[string]string{"aa": "bb"})},
+		{args: `aa=>null`, wantHstore: Hstore{"aa": nil}},
+		{args: `aa=>NuLl`, wantHstore: Hstore{"aa": nil}},
+		{args: `aa=>"NuLl"`, wantHstore: FromMap(map[string]string{"aa": "NuLl"})},
+		{args: `"\"a"=>"q>w"`, wantHstore: FromMap(map[string]string{`"a`: "q>w"})},
+		{args: `a\=b=>c\\d`, wantHstore: FromMap(map[string]string{"a=b": `c\d`})},
+		{args: `""=>""`, wantHstore: FromMap(map[string]string{"": ""})},
+	}
+	for i, tt := range tests {
+		tt := tt
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+			hs, err := ParseStrict(tt.args)
+			require.NoError(t, err)
+			require.True(t, hs.Equals(tt.wantHstore))
+		})
+	}
+}
+
+func TestParseStrict_errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		args    string
+		wantErr *ParseError
+	}{
+		{args: `"a=>b`, wantErr: &ParseError{Offset: 5, Expected: `closing '"'`}},
+		{args: `a=>"b`, wantErr: &ParseError{Offset: 5, Expected: `closing '"'`}},
+		{args: `a=>b\`, wantErr: &ParseError{Offset: 5, Expected: "escaped character"}},
+		{args: `a b`, wantErr: &ParseError{Offset: 2, Char: 'b', Expected: `"=>"`}},
+		{args: `a=b`, wantErr: &ParseError{Offset: 2, Char: 'b', Expected: `"=>"`}},
+		{args: `a=>`, wantErr: &ParseError{Offset: 3, Expected: "value"}},
+		{args: `a=>>b`, wantErr: &ParseError{Offset: 3, Char: '>', Expected: "value"}},
+		{args: `a=>b>`, wantErr: &ParseError{Offset: 4, Char: '>', Expected: `"," or end of input`}},
+		{args: `a>b=>c`, wantErr: &ParseError{Offset: 1, Char: '>', Expected: `"=>"`}},
+		{args: `a=>b=>c`, wantErr: &ParseError{Offset: 4, Char: '=', Expected: `"," or end of input`}},
+		{args: `a=>b c=>d`, wantErr: &ParseError{Offset: 5, Char: 'c', Expected: `"," or end of input`}},
+		{args: `=>b`, wantErr: &ParseError{Offset: 0, Char: '=', Expected: "key"}},
+		{args: `a=>b,,c=>d`, wantErr: &ParseError{Offset: 5, Char: ',', Expected: "key"}},
+	}
+	for i, tt := range tests {
+		tt := tt
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+			hs, err := ParseStrict(tt.args)
+			require.Nil(t, hs)
+
+			var parseErr *ParseError
+			require.True(t, errors.As(err, &parseErr))
+			require.Equal(t, tt.wantErr, parseErr)
+		})
+	}
+}
+
+func TestHstore_ScanStrict(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+		var hs Hstore
+		require.NoError(t, hs.ScanStrict([]byte(`"a"=>"b", "c"=>NULL`)))
+		require.True(t, hs.Equals(Hstore{"a": hs.Get("a"), "c": nil}))
+		require.Equal(t, "b", hs.GetString("a"))
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		t.Parallel()
+		var hs Hstore
+		require.NoError(t, hs.ScanStrict(nil))
+		require.Nil(t, hs)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		t.Parallel()
+		var hs Hstore
+		err := hs.ScanStrict(`"a"=>"b`)
+		require.EqualError(t, err, `hstore: unexpected end of input at offset 7, expected closing '"'`)
+		require.Nil(t, hs)
+	})
+
+	t.Run("invalid type", func(t *testing.T) {
+		t.Parallel()
+		var hs Hstore
+		require.EqualError(t, hs.ScanStrict(1), "invalid input type: int")
+	})
+}
@@ -0,0 +1,155 @@
+package enthstore
+
+import (
+	"sort"
+)
+
+// Change is the change of the value of a key, a nil
+// value represents a NULL value.
+type Change struct {
+	Old *string `json:"old"`
+	New *string `json:"new"`
+}
+
+// Patch is the difference between two Hstore, created by Diff.
+type Patch struct {
+	// Added holds the pairs that only exist on the new Hstore.
+	Added Hstore `json:"added,omitempty"`
+	// Removed holds the pairs that only exist on the old Hstore.
+	Removed Hstore `json:"removed,omitempty"`
+	// Changed holds the keys that exist on both with different values,
+	// including the changes from and to NULL.
+	Changed map[string]Change `json:"changed,omitempty"`
+}
+
+// Diff returns the Patch that transforms the old Hstore into the new one.
+func Diff(old, new Hstore) Patch {
+	p := Patch{}
+	for key, v1 := range old {
+		v2, found := new[key]
+		if !found {
+			p.remove(key, v1)
+			continue
+		}
+
+		if !equalValues(v1, v2) {
+			p.change(key, Change{Old: v1, New: v2})
+		}
+	}
+
+	for key, v2 := range new {
+		if _, found := old[key]; !found {
+			p.add(key, v2)
+		}
+	}
+
+	return p
+}
+
+// IsEmpty reports whether the patch has no changes.
+func (p Patch) IsEmpty() bool {
+	return len(p.Added) == 0 && len(p.Removed) == 0 && len(p.Changed) == 0
+}
+
+// Apply returns a copy of the Hstore with the patch applied,
+// the provided Hstore is not modified.
+func (p Patch) Apply(h Hstore) Hstore {
+	if h == nil && p.IsEmpty() {
+		return nil
+	}
+
+	result := make(Hstore, len(h)+len(p.Added))
+	for key, val := range h {
+		result[key] = val
+	}
+
+	for key := range p.Removed {
+		delete(result, key)
+	}
+
+	for key, val := range p.Added {
+		result[key] = val
+	}
+
+	for key, c := range p.Changed {
+		result[key] = c.New
+	}
+
+	return result
+}
+
+// Invert returns the patch that reverts the changes of the patch.
+func (p Patch) Invert() Patch {
+	inverted := Patch{}
+	for key, val := range p.Added {
+		inverted.remove(key, val)
+	}
+
+	for key, val := range p.Removed {
+		inverted.add(key, val)
+	}
+
+	for key, c := range p.Changed {
+		inverted.change(key, Change{Old: c.New, New: c.Old})
+	}
+
+	return inverted
+}
+
+// upserts returns the pairs added or changed by the patch.
+func (p Patch) upserts() Hstore {
+	h := make(Hstore, len(p.Added)+len(p.Changed))
+	for key, val := range p.Added {
+		h[key] = val
+	}
+
+	for key, c := range p.Changed {
+		h[key] = c.New
+	}
+
+	return h
+}
+
+// removedKeys returns the keys removed by the patch, sorted.
+func (p Patch) removedKeys() []string {
+	keys := make([]string, 0, len(p.Removed))
+	for key := range p.Removed {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+func (p *Patch) add(key string, val *string) {
+	if p.Added == nil {
+		p.Added = Hstore{}
+	}
+
+	p.Added[key] = val
+}
+
+func (p *Patch) remove(key string, val *string) {
+	if p.Removed == nil {
+		p.Removed = Hstore{}
+	}
+
+	p.Removed[key] = val
+}
+
+func (p *Patch) change(key string, c Change) {
+	if p.Changed == nil {
+		p.Changed = make(map[string]Change)
+	}
+
+	p.Changed[key] = c
+}
+
+// equalValues reports whether two values are equal, two NULL values are equal.
+func equalValues(v1, v2 *string) bool {
+	if v1 == nil || v2 == nil {
+		return v1 == nil && v2 == nil
+	}
+
+	return *v1 == *v2
+}
@@ -0,0 +1,78 @@
+package enthstore
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		old  Hstore
+		new  Hstore
+		want Patch
+	}{
+		{old: nil, new: nil, want: Patch{}},
+		{old: Hstore{"a": ptrString("b")}, new: Hstore{"a": ptrString("b")}, want: Patch{}},
+		{old: Hstore{"a": nil}, new: Hstore{"a": nil}, want: Patch{}},
+		{
+			old: Hstore{"a": ptrString("b"), "c": ptrString("d"), "e": nil, "f": ptrString("g"), "h": nil},
+			new: Hstore{"a": ptrString("b"), "c": ptrString("x"), "e": ptrString("y"), "f": nil, "i": nil},
+			want: Patch{
+				Added:   Hstore{"i": nil},
+				Removed: Hstore{"h": nil},
+				Changed: map[string]Change{
+					"c": {Old: ptrString("d"), New: ptrString("x")},
+					"e": {Old: nil, New: ptrString("y")},
+					"f": {Old: ptrString("g"), New: nil},
+				},
+			},
+		},
+	}
+	for i, tt := range tests {
+		tt := tt
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+			p := Diff(tt.old, tt.new)
+			require.Equal(t, tt.want, p)
+			require.Equal(t, tt.want.IsEmpty(), p.IsEmpty())
+
+			require.True(t, tt.new.Equals(p.Apply(tt.old)))
+			require.True(t, tt.old.Equals(p.Invert().Apply(tt.new)))
+		})
+	}
+}
+
+func TestPatch_Apply(t *testing.T) {
+	t.Parallel()
+
+	old := Hstore{"a": ptrString("b")}
+	p := Diff(old, Hstore{"c": ptrString("d")})
+
+	require.True(t, Hstore{"c": ptrString("d")}.Equals(p.Apply(old)))
+	require.True(t, Hstore{"a": ptrString("b")}.Equals(old))
+	require.True(t, Hstore{"c": ptrString("d")}.Equals(p.Apply(nil)))
+	require.Nil(t, Patch{}.Apply(nil))
+}
+
+func TestPatch_JSON(t *testing.T) {
+	t.Parallel()
+
+	p := Diff(Hstore{"a": ptrString("b"), "c": nil}, Hstore{"a": nil, "d": ptrString("e")})
+
+	data, err := json.Marshal(p)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"added":{"d":"e"},"removed":{"c":null},"changed":{"a":{"old":"b","new":null}}}`, string(data))
+
+	var decoded Patch
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, p, decoded)
+
+	data, err = json.Marshal(Patch{})
+	require.NoError(t, err)
+	require.Equal(t, `{}`, string(data))
+}
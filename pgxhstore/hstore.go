@@ -0,0 +1,304 @@
+// Package pgxhstore provides the Postgres hstore type for the pgx driver,
+// supporting both the text and the binary protocol.
+//
+// The hstore type is defined by an extension, so its OID is only known
+// after connecting, use Register on every new connection, for example on
+// pgxpool.Config.AfterConnect.
+package pgxhstore
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/crossworth/enthstore"
+	"github.com/jackc/pgio"
+	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
+)
+
+// TypeName is the name of the Postgres hstore type.
+const TypeName = "hstore"
+
+var errUndefined = errors.New("cannot encode status undefined")
+
+// Register looks up the OID of the hstore type and registers
+// it on the connection, the hstore extension must be enabled.
+func Register(ctx context.Context, conn *pgx.Conn) error {
+	var oid uint32
+	err := conn.QueryRow(ctx, "SELECT $1::regtype::oid", TypeName).Scan(&oid)
+	if err != nil {
+		return fmt.Errorf("could not find the hstore oid: %w", err)
+	}
+
+	RegisterDataType(conn.ConnInfo(), oid)
+	return nil
+}
+
+// RegisterDataType registers the hstore type with the provided OID, values
+// of enthstore.Hstore are mapped to it when the parameter type is unknown.
+func RegisterDataType(ci *pgtype.ConnInfo, oid uint32) {
+	ci.RegisterDataType(pgtype.DataType{
+		Value: &Hstore{},
+		Name:  TypeName,
+		OID:   oid,
+	})
+	ci.RegisterDefaultPgType(enthstore.Hstore{}, TypeName)
+}
+
+// Hstore implements pgtype.Value for the hstore type.
+type Hstore struct {
+	Map    enthstore.Hstore
+	Status pgtype.Status
+}
+
+// Set implements the interface pgtype.Value.
+func (h *Hstore) Set(src interface{}) error {
+	if src == nil {
+		*h = Hstore{Status: pgtype.Null}
+		return nil
+	}
+
+	switch value := src.(type) {
+	case enthstore.Hstore:
+		h.setMap(value)
+	case *enthstore.Hstore:
+		if value == nil {
+			*h = Hstore{Status: pgtype.Null}
+			return nil
+		}
+
+		h.setMap(*value)
+	case map[string]*string:
+		h.setMap(value)
+	case map[string]string:
+		h.setMap(enthstore.FromMap(value))
+	case Hstore:
+		*h = value
+	default:
+		return fmt.Errorf("cannot convert %v to Hstore", src)
+	}
+
+	return nil
+}
+
+func (h *Hstore) setMap(m enthstore.Hstore) {
+	if m == nil {
+		*h = Hstore{Status: pgtype.Null}
+		return
+	}
+
+	*h = Hstore{Map: m, Status: pgtype.Present}
+}
+
+// Get implements the interface pgtype.Value.
+func (h Hstore) Get() interface{} {
+	switch h.Status {
+	case pgtype.Present:
+		return h.Map
+	case pgtype.Null:
+		return nil
+	default:
+		return h.Status
+	}
+}
+
+// AssignTo implements the interface pgtype.Value.
+func (h *Hstore) AssignTo(dst interface{}) error {
+	switch h.Status {
+	case pgtype.Present:
+		switch v := dst.(type) {
+		case *enthstore.Hstore:
+			*v = h.Map
+			return nil
+		case *map[string]*string:
+			*v = h.Map
+			return nil
+		case *map[string]string:
+			m := make(map[string]string, len(h.Map))
+			for key, val := range h.Map {
+				if val == nil {
+					return fmt.Errorf("cannot decode %#v into %T, the key %q is NULL", h, dst, key)
+				}
+
+				m[key] = *val
+			}
+
+			*v = m
+			return nil
+		default:
+			if nextDst, retry := pgtype.GetAssignToDstType(dst); retry {
+				return h.AssignTo(nextDst)
+			}
+
+			return fmt.Errorf("unable to assign to %T", dst)
+		}
+	case pgtype.Null:
+		return pgtype.NullAssignTo(dst)
+	}
+
+	return fmt.Errorf("cannot decode %#v into %T", h, dst)
+}
+
+// DecodeText implements the interface pgtype.TextDecoder.
+func (h *Hstore) DecodeText(ci *pgtype.ConnInfo, src []byte) error {
+	if src == nil {
+		*h = Hstore{Status: pgtype.Null}
+		return nil
+	}
+
+	hs, err := enthstore.ParseStrict(string(src))
+	if err != nil {
+		return err
+	}
+
+	*h = Hstore{Map: hs, Status: pgtype.Present}
+	return nil
+}
+
+// DecodeBinary implements the interface pgtype.BinaryDecoder.
+//
+// The binary format is the number of pairs as an int32, followed
+// by every key and value prefixed by its length as an int32,
+// a length of -1 represents a NULL value.
+func (h *Hstore) DecodeBinary(ci *pgtype.ConnInfo, src []byte) error {
+	if src == nil {
+		*h = Hstore{Status: pgtype.Null}
+		return nil
+	}
+
+	rp := 0
+
+	count, err := readInt32(src, &rp)
+	if err != nil {
+		return err
+	}
+
+	if count < 0 {
+		return fmt.Errorf("hstore invalid pair count: %d", count)
+	}
+
+	hs := make(enthstore.Hstore, count)
+	for i := int32(0); i < count; i++ {
+		key, isNull, err := readString(src, &rp)
+		if err != nil {
+			return err
+		}
+
+		if isNull {
+			return fmt.Errorf("hstore key %d is NULL", i)
+		}
+
+		val, isNull, err := readString(src, &rp)
+		if err != nil {
+			return err
+		}
+
+		if isNull {
+			hs.Set(key, nil)
+		} else {
+			hs.SetString(key, val)
+		}
+	}
+
+	if rp != len(src) {
+		return fmt.Errorf("hstore has %d unexpected trailing bytes", len(src)-rp)
+	}
+
+	*h = Hstore{Map: hs, Status: pgtype.Present}
+	return nil
+}
+
+func readInt32(src []byte, rp *int) (int32, error) {
+	if len(src[*rp:]) < 4 {
+		return 0, fmt.Errorf("hstore incomplete %v", src)
+	}
+
+	n := int32(binary.BigEndian.Uint32(src[*rp:]))
+	*rp += 4
+	return n, nil
+}
+
+func readString(src []byte, rp *int) (string, bool, error) {
+	n, err := readInt32(src, rp)
+	if err != nil {
+		return "", false, err
+	}
+
+	if n == -1 {
+		return "", true, nil
+	}
+
+	if n < 0 || len(src[*rp:]) < int(n) {
+		return "", false, fmt.Errorf("hstore incomplete %v", src)
+	}
+
+	s := string(src[*rp : *rp+int(n)])
+	*rp += int(n)
+	return s, false, nil
+}
+
+// EncodeText implements the interface pgtype.TextEncoder.
+func (h Hstore) EncodeText(ci *pgtype.ConnInfo, buf []byte) ([]byte, error) {
+	switch h.Status {
+	case pgtype.Null:
+		return nil, nil
+	case pgtype.Undefined:
+		return nil, errUndefined
+	}
+
+	return append(buf, h.Map.Canonical()...), nil
+}
+
+// EncodeBinary implements the interface pgtype.BinaryEncoder.
+//
+// The pairs are encoded in canonical order, like EncodeText.
+func (h Hstore) EncodeBinary(ci *pgtype.ConnInfo, buf []byte) ([]byte, error) {
+	switch h.Status {
+	case pgtype.Null:
+		return nil, nil
+	case pgtype.Undefined:
+		return nil, errUndefined
+	}
+
+	buf = pgio.AppendInt32(buf, int32(len(h.Map)))
+	for _, key := range h.Map.Keys() {
+		buf = pgio.AppendInt32(buf, int32(len(key)))
+		buf = append(buf, key...)
+
+		val := h.Map[key]
+		if val == nil {
+			buf = pgio.AppendInt32(buf, -1)
+			continue
+		}
+
+		buf = pgio.AppendInt32(buf, int32(len(*val)))
+		buf = append(buf, *val...)
+	}
+
+	return buf, nil
+}
+
+// Scan implements the interface sql.Scanner.
+func (h *Hstore) Scan(src interface{}) error {
+	if src == nil {
+		*h = Hstore{Status: pgtype.Null}
+		return nil
+	}
+
+	switch src := src.(type) {
+	case string:
+		return h.DecodeText(nil, []byte(src))
+	case []byte:
+		return h.DecodeText(nil, src)
+	}
+
+	return fmt.Errorf("cannot scan %T", src)
+}
+
+// Value implements the interface driver.Valuer.
+func (h Hstore) Value() (driver.Value, error) {
+	return pgtype.EncodeValueText(h)
+}
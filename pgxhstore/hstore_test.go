@@ -0,0 +1,151 @@
+package pgxhstore
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/crossworth/enthstore"
+	"github.com/jackc/pgio"
+	"github.com/jackc/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHstore_Binary(t *testing.T) {
+	t.Parallel()
+
+	tests := []enthstore.Hstore{
+		{},
+		enthstore.FromMap(map[string]string{"a": "b"}),
+		enthstore.FromMap(map[string]string{"a'a": `b"b`, "": "", "tes t": "\nabc\t"}),
+		{"null": nil, "NULL": nil},
+	}
+	for i, tt := range tests {
+		tt := tt
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+			src := Hstore{}
+			require.NoError(t, src.Set(tt))
+
+			buf, err := src.EncodeBinary(nil, nil)
+			require.NoError(t, err)
+
+			var dst Hstore
+			require.NoError(t, dst.DecodeBinary(nil, buf))
+			require.Equal(t, pgtype.Present, dst.Status)
+			require.True(t, tt.Equals(dst.Map))
+		})
+	}
+}
+
+func TestHstore_EncodeBinaryCanonical(t *testing.T) {
+	t.Parallel()
+
+	src := Hstore{}
+	require.NoError(t, src.Set(enthstore.Hstore{"bb": nil, "b": nil, "a": nil}))
+
+	want := pgio.AppendInt32(nil, 3)
+	for _, key := range []string{"a", "b", "bb"} {
+		want = pgio.AppendInt32(want, int32(len(key)))
+		want = append(want, key...)
+		want = pgio.AppendInt32(want, -1)
+	}
+
+	// the map iteration order is random, the encoding must not be.
+	for i := 0; i < 10; i++ {
+		buf, err := src.EncodeBinary(nil, nil)
+		require.NoError(t, err)
+		require.Equal(t, want, buf)
+	}
+}
+
+func TestHstore_DecodeBinary(t *testing.T) {
+	t.Parallel()
+
+	buf := pgio.AppendInt32(nil, 2)
+	buf = pgio.AppendInt32(buf, 1)
+	buf = append(buf, 'a')
+	buf = pgio.AppendInt32(buf, 2)
+	buf = append(buf, "bc"...)
+	buf = pgio.AppendInt32(buf, 1)
+	buf = append(buf, 'n')
+	buf = pgio.AppendInt32(buf, -1)
+
+	var hs Hstore
+	require.NoError(t, hs.DecodeBinary(nil, buf))
+	require.True(t, hs.Map.Equals(enthstore.Hstore{"a": hs.Map.Get("a"), "n": nil}))
+	require.Equal(t, "bc", hs.Map.GetString("a"))
+
+	require.NoError(t, hs.DecodeBinary(nil, nil))
+	require.Equal(t, pgtype.Null, hs.Status)
+
+	require.Error(t, hs.DecodeBinary(nil, buf[:len(buf)-2]))
+	require.Error(t, hs.DecodeBinary(nil, append(buf, 0)))
+	require.Error(t, hs.DecodeBinary(nil, pgio.AppendInt32(pgio.AppendInt32(nil, 1), -1)))
+}
+
+func TestHstore_Text(t *testing.T) {
+	t.Parallel()
+
+	src := Hstore{}
+	require.NoError(t, src.Set(map[string]string{"b": "2", "a": "1"}))
+
+	buf, err := src.EncodeText(nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, `"a"=>"1","b"=>"2"`, string(buf))
+
+	var dst Hstore
+	require.NoError(t, dst.DecodeText(nil, buf))
+	require.True(t, src.Map.Equals(dst.Map))
+
+	require.Error(t, dst.DecodeText(nil, []byte(`"a"=>"1`)))
+}
+
+func TestHstore_SetAssignTo(t *testing.T) {
+	t.Parallel()
+
+	var hs Hstore
+	require.NoError(t, hs.Set(nil))
+	require.Equal(t, pgtype.Null, hs.Status)
+	require.Nil(t, hs.Get())
+
+	require.NoError(t, hs.Set(enthstore.Hstore(nil)))
+	require.Equal(t, pgtype.Null, hs.Status)
+
+	var out enthstore.Hstore
+	require.NoError(t, hs.AssignTo(&out))
+	require.Nil(t, out)
+
+	require.Error(t, hs.Set(1))
+
+	in := enthstore.FromMap(map[string]string{"a": "b"})
+	in.Set("c", nil)
+	require.NoError(t, hs.Set(&in))
+	require.Equal(t, pgtype.Present, hs.Status)
+
+	require.NoError(t, hs.AssignTo(&out))
+	require.True(t, in.Equals(out))
+
+	var m map[string]string
+	require.Error(t, hs.AssignTo(&m))
+
+	in.Del("c")
+	require.NoError(t, hs.Set(in))
+	require.NoError(t, hs.AssignTo(&m))
+	require.Equal(t, map[string]string{"a": "b"}, m)
+}
+
+func TestRegisterDataType(t *testing.T) {
+	t.Parallel()
+
+	ci := pgtype.NewConnInfo()
+	RegisterDataType(ci, 16000)
+
+	dt, ok := ci.DataTypeForOID(16000)
+	require.True(t, ok)
+	require.Equal(t, TypeName, dt.Name)
+
+	dt, ok = ci.DataTypeForValue(enthstore.Hstore{})
+	require.True(t, ok)
+	require.Equal(t, TypeName, dt.Name)
+	require.Equal(t, int16(pgtype.BinaryFormatCode), ci.ResultFormatCodeForOID(16000))
+}
@@ -1,21 +1,47 @@
 package enthstore
 
 import (
+	"fmt"
+	"regexp"
 	"strings"
 
+	"entgo.io/ent/dialect"
 	"entgo.io/ent/dialect/sql"
 )
 
-// HasKey checks if the given column has the provided key.
+// HasKey checks if the given column has the provided key, using the "?"
+// operator, which unlike the exist function can use the GIN and GiST indexes.
 func HasKey(column string, key string) *sql.Predicate {
-	return sql.P(func(b *sql.Builder) {
-		b.WriteString("exist(").Ident(column).Comma().WriteString(quoteKey(key)).WriteString(")")
-	})
+	return Matchable.HasKey(column, key).Predicate
+}
+
+// HasKey returns the HasKey predicate evaluable by Match.
+func (MatchablePredicates) HasKey(column string, key string) *Predicate {
+	return newPredicate(sql.P(func(b *sql.Builder) {
+		if isJSONDialect(b.Dialect()) {
+			writeJSONKeys(b, column, []string{key}, " AND ", "TRUE")
+			return
+		}
+
+		b.Ident(column).WriteString(" ? ").WriteString(quoteKey(key))
+	}), matchKeys(func(h Hstore) bool {
+		return h.Has(key)
+	}))
 }
 
 // HasAllKeys checks if the given column has all the keys provided.
 func HasAllKeys(column string, keys ...string) *sql.Predicate {
-	return sql.P(func(b *sql.Builder) {
+	return Matchable.HasAllKeys(column, keys...).Predicate
+}
+
+// HasAllKeys returns the HasAllKeys predicate evaluable by Match.
+func (MatchablePredicates) HasAllKeys(column string, keys ...string) *Predicate {
+	return newPredicate(sql.P(func(b *sql.Builder) {
+		if isJSONDialect(b.Dialect()) {
+			writeJSONKeys(b, column, keys, " AND ", "TRUE")
+			return
+		}
+
 		b.Ident(column).WriteString(" ?& ").
 			WriteString("ARRAY[")
 
@@ -25,71 +51,628 @@ func HasAllKeys(column string, keys ...string) *sql.Predicate {
 		}
 
 		b.WriteString(strings.Join(quoted, ",")).WriteString("]")
-	})
+	}), matchKeys(func(h Hstore) bool {
+		for _, k := range keys {
+			if !h.Has(k) {
+				return false
+			}
+		}
+
+		return true
+	}))
+}
+
+// HasAnyKeys checks if the given column has any of the keys provided.
+func HasAnyKeys(column string, keys ...string) *sql.Predicate {
+	return Matchable.HasAnyKeys(column, keys...).Predicate
+}
+
+// HasAnyKeys returns the HasAnyKeys predicate evaluable by Match.
+func (MatchablePredicates) HasAnyKeys(column string, keys ...string) *Predicate {
+	return newPredicate(sql.P(func(b *sql.Builder) {
+		if isJSONDialect(b.Dialect()) {
+			writeJSONKeys(b, column, keys, " OR ", "FALSE")
+			return
+		}
+
+		b.Ident(column).WriteString(" ?| ").
+			WriteString("ARRAY[")
+
+		quoted := make([]string, 0, len(keys))
+		for _, k := range keys {
+			quoted = append(quoted, quoteKey(k))
+		}
+
+		b.WriteString(strings.Join(quoted, ",")).WriteString("]")
+	}), matchKeys(func(h Hstore) bool {
+		for _, k := range keys {
+			if h.Has(k) {
+				return true
+			}
+		}
+
+		return false
+	}))
+}
+
+// NotHasKey checks if the given column doesn't have the provided key.
+func NotHasKey(column string, key string) *sql.Predicate {
+	return Matchable.NotHasKey(column, key).Predicate
+}
+
+// NotHasKey returns the NotHasKey predicate evaluable by Match.
+func (m MatchablePredicates) NotHasKey(column string, key string) *Predicate {
+	return m.Not(m.HasKey(column, key))
+}
+
+// Contains checks if the given column contains all the pairs of the provided Hstore.
+func Contains(column string, h Hstore) *sql.Predicate {
+	return Matchable.Contains(column, h).Predicate
+}
+
+// Contains returns the Contains predicate evaluable by Match.
+func (MatchablePredicates) Contains(column string, h Hstore) *Predicate {
+	return newPredicate(sql.P(func(b *sql.Builder) {
+		if isJSONDialect(b.Dialect()) {
+			writeJSONContains(b, column, h, false)
+			return
+		}
+
+		b.Ident(column).WriteString(" @> ").Arg(&h)
+	}), matchKeys(func(other Hstore) bool {
+		return contains(other, h)
+	}))
+}
+
+// NotContains checks if the given column doesn't contain all the pairs of the provided Hstore.
+func NotContains(column string, h Hstore) *sql.Predicate {
+	return Matchable.NotContains(column, h).Predicate
+}
+
+// NotContains returns the NotContains predicate evaluable by Match.
+func (m MatchablePredicates) NotContains(column string, h Hstore) *Predicate {
+	return m.Not(m.Contains(column, h))
+}
+
+// ContainedBy checks if all the pairs of the given column are contained by the provided Hstore.
+func ContainedBy(column string, h Hstore) *sql.Predicate {
+	return Matchable.ContainedBy(column, h).Predicate
+}
+
+// ContainedBy returns the ContainedBy predicate evaluable by Match.
+func (MatchablePredicates) ContainedBy(column string, h Hstore) *Predicate {
+	return newPredicate(sql.P(func(b *sql.Builder) {
+		if isJSONDialect(b.Dialect()) {
+			writeJSONContains(b, column, h, true)
+			return
+		}
+
+		b.Ident(column).WriteString(" <@ ").Arg(&h)
+	}), matchKeys(func(other Hstore) bool {
+		return contains(h, other)
+	}))
+}
+
+// NotContainedBy checks if the pairs of the given column are not contained by the provided Hstore.
+func NotContainedBy(column string, h Hstore) *sql.Predicate {
+	return Matchable.NotContainedBy(column, h).Predicate
+}
+
+// NotContainedBy returns the NotContainedBy predicate evaluable by Match.
+func (m MatchablePredicates) NotContainedBy(column string, h Hstore) *Predicate {
+	return m.Not(m.ContainedBy(column, h))
 }
 
 // ValueIsNull check if the given column has a key which the value is null.
 func ValueIsNull(column string, key string) *sql.Predicate {
-	return sql.P(func(b *sql.Builder) {
+	return Matchable.ValueIsNull(column, key).Predicate
+}
+
+// ValueIsNull returns the ValueIsNull predicate evaluable by Match.
+func (MatchablePredicates) ValueIsNull(column string, key string) *Predicate {
+	return newPredicate(sql.P(func(b *sql.Builder) {
+		if isJSONDialect(b.Dialect()) {
+			b.WriteString("(").Ident(column).WriteString(" IS NOT NULL AND ")
+			writeValue(b, column, key)
+			b.WriteString(" IS NULL)")
+			return
+		}
+
 		b.WriteString("defined(").Ident(column).Comma().WriteString(quoteKey(key)).WriteString(") is false")
+	}), func(h Hstore) (truth, error) {
+		// "is false" is never unknown, a NULL column is not null.
+		return truthOf(h != nil && h.Get(key) == nil), nil
 	})
 }
 
 // ValueEQ check if the given column has a key which the value is equals to the provided string.
 func ValueEQ(column string, key string, val string) *sql.Predicate {
-	return sql.P(func(b *sql.Builder) {
-		b.Ident(column).WriteString(" -> ").WriteString(quoteKey(key)).WriteOp(sql.OpEQ).Arg(val)
-	})
+	return Matchable.ValueEQ(column, key, val).Predicate
+}
+
+// ValueEQ returns the ValueEQ predicate evaluable by Match.
+func (MatchablePredicates) ValueEQ(column string, key string, val string) *Predicate {
+	return newPredicate(sql.P(func(b *sql.Builder) {
+		writeValue(b, column, key)
+		b.WriteOp(sql.OpEQ).Arg(val)
+	}), matchText(key, sql.OpEQ, val))
 }
 
 // ValueNEQ check if the given column has a key which the value is not equals to the provided string.
 func ValueNEQ(column string, key string, val string) *sql.Predicate {
-	return sql.P(func(b *sql.Builder) {
-		b.Ident(column).WriteString(" -> ").WriteString(quoteKey(key)).WriteOp(sql.OpNEQ).Arg(val)
-	})
+	return Matchable.ValueNEQ(column, key, val).Predicate
+}
+
+// ValueNEQ returns the ValueNEQ predicate evaluable by Match.
+func (MatchablePredicates) ValueNEQ(column string, key string, val string) *Predicate {
+	return newPredicate(sql.P(func(b *sql.Builder) {
+		writeValue(b, column, key)
+		b.WriteOp(sql.OpNEQ).Arg(val)
+	}), matchText(key, sql.OpNEQ, val))
 }
 
 // ValueGT check if the given column has a key which the value is greater than the provided string.
 func ValueGT(column string, key string, val string) *sql.Predicate {
-	return sql.P(func(b *sql.Builder) {
-		b.Ident(column).WriteString(" -> ").WriteString(quoteKey(key)).WriteOp(sql.OpGT).Arg(val)
-	})
+	return Matchable.ValueGT(column, key, val).Predicate
+}
+
+// ValueGT returns the ValueGT predicate evaluable by Match.
+func (MatchablePredicates) ValueGT(column string, key string, val string) *Predicate {
+	return newPredicate(sql.P(func(b *sql.Builder) {
+		writeValue(b, column, key)
+		b.WriteOp(sql.OpGT).Arg(val)
+	}), matchText(key, sql.OpGT, val))
 }
 
 // ValueGTE check if the given column has a key which the value is greater
 // or equals to the provided string.
 func ValueGTE(column string, key string, val string) *sql.Predicate {
-	return sql.P(func(b *sql.Builder) {
-		b.Ident(column).WriteString(" -> ").WriteString(quoteKey(key)).WriteOp(sql.OpGTE).Arg(val)
-	})
+	return Matchable.ValueGTE(column, key, val).Predicate
+}
+
+// ValueGTE returns the ValueGTE predicate evaluable by Match.
+func (MatchablePredicates) ValueGTE(column string, key string, val string) *Predicate {
+	return newPredicate(sql.P(func(b *sql.Builder) {
+		writeValue(b, column, key)
+		b.WriteOp(sql.OpGTE).Arg(val)
+	}), matchText(key, sql.OpGTE, val))
 }
 
 // ValueLT check if the given column has a key which the value is smaller than the provided string.
 func ValueLT(column string, key string, val string) *sql.Predicate {
-	return sql.P(func(b *sql.Builder) {
-		b.Ident(column).WriteString(" -> ").WriteString(quoteKey(key)).WriteOp(sql.OpLT).Arg(val)
-	})
+	return Matchable.ValueLT(column, key, val).Predicate
+}
+
+// ValueLT returns the ValueLT predicate evaluable by Match.
+func (MatchablePredicates) ValueLT(column string, key string, val string) *Predicate {
+	return newPredicate(sql.P(func(b *sql.Builder) {
+		writeValue(b, column, key)
+		b.WriteOp(sql.OpLT).Arg(val)
+	}), matchText(key, sql.OpLT, val))
 }
 
 // ValueLTE check if the given column has a key which the value is smaller
 // or equals to the provided string.
 func ValueLTE(column string, key string, val string) *sql.Predicate {
-	return sql.P(func(b *sql.Builder) {
-		b.Ident(column).WriteString(" -> ").WriteString(quoteKey(key)).WriteOp(sql.OpLTE).Arg(val)
-	})
+	return Matchable.ValueLTE(column, key, val).Predicate
+}
+
+// ValueLTE returns the ValueLTE predicate evaluable by Match.
+func (MatchablePredicates) ValueLTE(column string, key string, val string) *Predicate {
+	return newPredicate(sql.P(func(b *sql.Builder) {
+		writeValue(b, column, key)
+		b.WriteOp(sql.OpLTE).Arg(val)
+	}), matchText(key, sql.OpLTE, val))
+}
+
+// ValueIn check if the given column has a key which the value is one of the provided strings.
+// A NULL value is unknown, like ValueEQ, and an empty list is always false, like sql.In.
+func ValueIn(column string, key string, vals ...string) *sql.Predicate {
+	return Matchable.ValueIn(column, key, vals...).Predicate
+}
+
+// ValueIn returns the ValueIn predicate evaluable by Match.
+func (MatchablePredicates) ValueIn(column string, key string, vals ...string) *Predicate {
+	return newPredicate(sql.P(func(b *sql.Builder) {
+		writeIn(b, column, key, vals, sql.OpIn, "FALSE")
+	}), matchIn(key, vals, false))
+}
+
+// ValueNotIn check if the given column has a key which the value is none of the provided strings.
+// A NULL value or a missing key is unknown, so these rows are not selected by ValueIn or ValueNotIn,
+// and an empty list is always true, like sql.NotIn.
+func ValueNotIn(column string, key string, vals ...string) *sql.Predicate {
+	return Matchable.ValueNotIn(column, key, vals...).Predicate
+}
+
+// ValueNotIn returns the ValueNotIn predicate evaluable by Match.
+func (MatchablePredicates) ValueNotIn(column string, key string, vals ...string) *Predicate {
+	return newPredicate(sql.P(func(b *sql.Builder) {
+		writeIn(b, column, key, vals, sql.OpNotIn, "NOT (FALSE)")
+	}), matchIn(key, vals, true))
 }
 
 // ValueContains check given column has a key which the value contains the provided string.
 func ValueContains(column string, key, val string) *sql.Predicate {
-	return sql.P().Contains(sql.P().Ident(column).WriteString(" -> ").WriteString(quoteKey(key)).String(), val)
+	return Matchable.ValueContains(column, key, val).Predicate
+}
+
+// ValueContains returns the ValueContains predicate evaluable by Match.
+func (MatchablePredicates) ValueContains(column string, key, val string) *Predicate {
+	return newPredicate(sql.P(func(b *sql.Builder) {
+		writeLike(b, column, key, "%", val, "%")
+	}), matchValue(key, func(v string) (truth, error) {
+		return truthOf(strings.Contains(v, val)), nil
+	}))
 }
 
 // ValueHasPrefix check given column has a key which the value the provided prefix.
 func ValueHasPrefix(column string, key, val string) *sql.Predicate {
-	return sql.P().HasPrefix(sql.P().Ident(column).WriteString(" -> ").WriteString(quoteKey(key)).String(), val)
+	return Matchable.ValueHasPrefix(column, key, val).Predicate
+}
+
+// ValueHasPrefix returns the ValueHasPrefix predicate evaluable by Match.
+func (MatchablePredicates) ValueHasPrefix(column string, key, val string) *Predicate {
+	return newPredicate(sql.P(func(b *sql.Builder) {
+		writeLike(b, column, key, "", val, "%")
+	}), matchValue(key, func(v string) (truth, error) {
+		return truthOf(strings.HasPrefix(v, val)), nil
+	}))
 }
 
 // ValueHasSuffix check given column has a key which the value the provided suffix.
 func ValueHasSuffix(column string, key, val string) *sql.Predicate {
-	return sql.P().HasSuffix(sql.P().Ident(column).WriteString(" -> ").WriteString(quoteKey(key)).String(), val)
+	return Matchable.ValueHasSuffix(column, key, val).Predicate
+}
+
+// ValueHasSuffix returns the ValueHasSuffix predicate evaluable by Match.
+func (MatchablePredicates) ValueHasSuffix(column string, key, val string) *Predicate {
+	return newPredicate(sql.P(func(b *sql.Builder) {
+		writeLike(b, column, key, "%", val, "")
+	}), matchValue(key, func(v string) (truth, error) {
+		return truthOf(strings.HasSuffix(v, val)), nil
+	}))
+}
+
+// ValueEqualFold check if the given column has a key which the value is equals
+// to the provided string, using case-folding.
+func ValueEqualFold(column string, key, val string) *sql.Predicate {
+	return Matchable.ValueEqualFold(column, key, val).Predicate
+}
+
+// ValueEqualFold returns the ValueEqualFold predicate evaluable by Match.
+func (MatchablePredicates) ValueEqualFold(column string, key, val string) *Predicate {
+	return newPredicate(sql.P(func(b *sql.Builder) {
+		switch b.Dialect() {
+		case dialect.MySQL:
+			writeValue(b, column, key)
+			b.WriteString(" COLLATE utf8mb4_general_ci = ").Arg(strings.ToLower(val))
+		case dialect.SQLite:
+			b.WriteString("lower(")
+			writeValue(b, column, key)
+			b.WriteString(")").WriteOp(sql.OpEQ).Arg(strings.ToLower(val))
+		default:
+			writeValue(b, column, key)
+			b.WriteString(" ILIKE ").Arg(escapeLike(strings.ToLower(val)))
+		}
+	}), matchValue(key, func(v string) (truth, error) {
+		return truthOf(strings.ToLower(v) == strings.ToLower(val)), nil
+	}))
+}
+
+// ValueContainsFold check given column has a key which the value contains
+// the provided string, using case-folding.
+func ValueContainsFold(column string, key, val string) *sql.Predicate {
+	return Matchable.ValueContainsFold(column, key, val).Predicate
+}
+
+// ValueContainsFold returns the ValueContainsFold predicate evaluable by Match.
+func (MatchablePredicates) ValueContainsFold(column string, key, val string) *Predicate {
+	return newPredicate(sql.P(func(b *sql.Builder) {
+		switch b.Dialect() {
+		case dialect.MySQL:
+			writeValue(b, column, key)
+			b.WriteString(" COLLATE utf8mb4_general_ci LIKE ").Arg("%" + escapeLike(strings.ToLower(val)) + "%")
+		case dialect.SQLite:
+			b.WriteString("instr(lower(")
+			writeValue(b, column, key)
+			b.WriteString(")").Comma().Arg(strings.ToLower(val)).WriteString(") > 0")
+		default:
+			writeValue(b, column, key)
+			b.WriteString(" ILIKE ").Arg("%" + escapeLike(strings.ToLower(val)) + "%")
+		}
+	}), matchValue(key, func(v string) (truth, error) {
+		return truthOf(strings.Contains(strings.ToLower(v), strings.ToLower(val))), nil
+	}))
+}
+
+// ValueLike check given column has a key which the value matches the LIKE pattern,
+// the wildcards of the pattern are not escaped.
+func ValueLike(column string, key, pattern string) *sql.Predicate {
+	return Matchable.ValueLike(column, key, pattern).Predicate
+}
+
+// ValueLike returns the ValueLike predicate evaluable by Match.
+func (MatchablePredicates) ValueLike(column string, key, pattern string) *Predicate {
+	return newPredicate(sql.P(func(b *sql.Builder) {
+		writePattern(b, column, key, pattern, "")
+	}), matchLike(key, pattern, false))
+}
+
+// ValueNotLike check given column has a key which the value doesn't match the LIKE pattern,
+// the wildcards of the pattern are not escaped.
+func ValueNotLike(column string, key, pattern string) *sql.Predicate {
+	return Matchable.ValueNotLike(column, key, pattern).Predicate
+}
+
+// ValueNotLike returns the ValueNotLike predicate evaluable by Match.
+func (MatchablePredicates) ValueNotLike(column string, key, pattern string) *Predicate {
+	return newPredicate(sql.P(func(b *sql.Builder) {
+		writePattern(b, column, key, pattern, "NOT ")
+	}), matchLike(key, pattern, true))
+}
+
+// ValueRegex check given column has a key which the value matches the
+// POSIX regular expression, using the "~" operator.
+func ValueRegex(column string, key, pattern string) *sql.Predicate {
+	return Matchable.ValueRegex(column, key, pattern).Predicate
+}
+
+// ValueRegex returns the ValueRegex predicate evaluable by Match.
+func (MatchablePredicates) ValueRegex(column string, key, pattern string) *Predicate {
+	return newPredicate(sql.P(func(b *sql.Builder) {
+		writeRegex(b, column, key, pattern, false)
+	}), matchRegex(key, pattern))
+}
+
+// ValueRegexFold check given column has a key which the value matches the POSIX
+// regular expression with case-folding, using the "~*" operator.
+func ValueRegexFold(column string, key, pattern string) *sql.Predicate {
+	return Matchable.ValueRegexFold(column, key, pattern).Predicate
+}
+
+// ValueRegexFold returns the ValueRegexFold predicate evaluable by Match.
+func (MatchablePredicates) ValueRegexFold(column string, key, pattern string) *Predicate {
+	return newPredicate(sql.P(func(b *sql.Builder) {
+		writeRegex(b, column, key, pattern, true)
+	}), matchRegex(key, "(?i)"+pattern))
+}
+
+// ValueSimilarTo check given column has a key which the value matches the
+// SQL regular expression, using the "SIMILAR TO" operator of Postgres.
+func ValueSimilarTo(column string, key, pattern string) *sql.Predicate {
+	return Matchable.ValueSimilarTo(column, key, pattern).Predicate
+}
+
+// ValueSimilarTo returns the ValueSimilarTo predicate evaluable by Match.
+func (MatchablePredicates) ValueSimilarTo(column string, key, pattern string) *Predicate {
+	return newPredicate(sql.P(func(b *sql.Builder) {
+		if isJSONDialect(b.Dialect()) {
+			b.AddError(fmt.Errorf("hstore: SIMILAR TO is not supported by %s", b.Dialect()))
+			return
+		}
+
+		writeValue(b, column, key)
+		b.WriteString(" SIMILAR TO ").Arg(pattern)
+	}), matchSimilarTo(key, pattern))
+}
+
+// AnyValueEQ check if the given column has any key which the value is equals to the provided string,
+// using "avals(col) @> ARRAY[val]". The predicate can use the GIN index created by ValuesIndex.
+func AnyValueEQ(column string, val string) *sql.Predicate {
+	return Matchable.AnyValueEQ(column, val).Predicate
+}
+
+// AnyValueEQ returns the AnyValueEQ predicate evaluable by Match.
+func (MatchablePredicates) AnyValueEQ(column string, val string) *Predicate {
+	return newPredicate(sql.P(func(b *sql.Builder) {
+		switch b.Dialect() {
+		case dialect.MySQL:
+			b.WriteString("CASE WHEN ").Ident(column).WriteString(" IS NOT NULL THEN COALESCE(JSON_CONTAINS(JSON_EXTRACT(").
+				Ident(column).WriteString(", '$.*'), JSON_QUOTE(").Arg(val).WriteString(")), FALSE) END")
+		case dialect.SQLite:
+			b.WriteString("CASE WHEN ").Ident(column).WriteString(" IS NOT NULL THEN EXISTS (SELECT 1 FROM json_each(").
+				Ident(column).WriteString(") WHERE json_each.type = 'text' AND json_each.value = ").Arg(val).WriteString(") END")
+		default:
+			b.WriteString("avals(").Ident(column).WriteString(") @> ")
+			textArray(b, []string{val})
+		}
+	}), matchKeys(func(h Hstore) bool {
+		for _, v := range h {
+			if v != nil && *v == val {
+				return true
+			}
+		}
+
+		return false
+	}))
+}
+
+// AnyValueContains check if the given column has any key which the value contains the provided string.
+// The values are read with svals and can't use an index, a NULL column is false.
+func AnyValueContains(column string, val string) *sql.Predicate {
+	return Matchable.AnyValueContains(column, val).Predicate
+}
+
+// AnyValueContains returns the AnyValueContains predicate evaluable by Match.
+func (MatchablePredicates) AnyValueContains(column string, val string) *Predicate {
+	return newPredicate(sql.P(func(b *sql.Builder) {
+		switch b.Dialect() {
+		case dialect.MySQL:
+			b.WriteString("JSON_SEARCH(").Ident(column).WriteString(", 'one', ").
+				Arg("%" + escapeLike(val) + "%").WriteString(") IS NOT NULL")
+		case dialect.SQLite:
+			b.WriteString("EXISTS (SELECT 1 FROM json_each(").Ident(column).
+				WriteString(") WHERE instr(json_each.value, ").Arg(val).WriteString(") > 0)")
+		default:
+			b.WriteString("EXISTS (SELECT 1 FROM svals(").Ident(column).WriteString(") AS v WHERE v LIKE ").
+				Arg("%" + escapeLike(val) + "%").WriteString(")")
+		}
+	}), func(h Hstore) (truth, error) {
+		for _, v := range h {
+			if v != nil && strings.Contains(*v, val) {
+				return isTrue, nil
+			}
+		}
+
+		return isFalse, nil
+	})
+}
+
+// KeyMatches check if the given column has any key that matches the POSIX regular expression.
+// The keys are read with skeys and can't use an index, a NULL column is false.
+func KeyMatches(column string, pattern string) *sql.Predicate {
+	return Matchable.KeyMatches(column, pattern).Predicate
+}
+
+// KeyMatches returns the KeyMatches predicate evaluable by Match.
+func (MatchablePredicates) KeyMatches(column string, pattern string) *Predicate {
+	re, err := regexp.Compile(pattern)
+	return newPredicate(sql.P(func(b *sql.Builder) {
+		switch b.Dialect() {
+		case dialect.MySQL:
+			b.WriteString("EXISTS (SELECT 1 FROM JSON_TABLE(JSON_KEYS(").Ident(column).
+				WriteString("), '$[*]' COLUMNS (k TEXT PATH '$')) AS jk WHERE REGEXP_LIKE(jk.k, ").
+				Arg(pattern).WriteString(", 'c'))")
+		case dialect.SQLite:
+			b.WriteString("EXISTS (SELECT 1 FROM json_each(").Ident(column).
+				WriteString(") WHERE json_each.key REGEXP ").Arg(pattern).WriteString(")")
+		default:
+			b.WriteString("EXISTS (SELECT 1 FROM skeys(").Ident(column).WriteString(") AS k WHERE k ~ ").
+				Arg(pattern).WriteString(")")
+		}
+	}), func(h Hstore) (truth, error) {
+		if err != nil {
+			return unknown, fmt.Errorf("hstore: invalid regular expression: %w", err)
+		}
+
+		for key := range h {
+			if re.MatchString(key) {
+				return isTrue, nil
+			}
+		}
+
+		return isFalse, nil
+	})
+}
+
+// IsEmpty check if the given column is an empty hstore, comparing it with an empty hstore literal.
+// A NULL column is not empty, it's unknown, like the other predicates.
+func IsEmpty(column string) *sql.Predicate {
+	return Matchable.IsEmpty(column).Predicate
+}
+
+// IsEmpty returns the IsEmpty predicate evaluable by Match.
+func (MatchablePredicates) IsEmpty(column string) *Predicate {
+	return newPredicate(sql.P(func(b *sql.Builder) {
+		if isJSONDialect(b.Dialect()) {
+			writeKeyCount(b, column)
+			b.WriteString(" = 0")
+			return
+		}
+
+		b.Ident(column).WriteString(" = ''::hstore")
+	}), matchKeys(func(h Hstore) bool {
+		return len(h) == 0
+	}))
+}
+
+// IsNotEmpty check if the given column is an hstore with at least one key.
+// A NULL column is unknown, so these rows are not selected by IsEmpty or IsNotEmpty.
+func IsNotEmpty(column string) *sql.Predicate {
+	return Matchable.IsNotEmpty(column).Predicate
+}
+
+// IsNotEmpty returns the IsNotEmpty predicate evaluable by Match.
+func (MatchablePredicates) IsNotEmpty(column string) *Predicate {
+	return newPredicate(sql.P(func(b *sql.Builder) {
+		if isJSONDialect(b.Dialect()) {
+			writeKeyCount(b, column)
+			b.WriteString(" <> 0")
+			return
+		}
+
+		b.Ident(column).WriteString(" <> ''::hstore")
+	}), matchKeys(func(h Hstore) bool {
+		return len(h) > 0
+	}))
+}
+
+// KeyCountEQ check if the given column has exactly n keys.
+func KeyCountEQ(column string, n int) *sql.Predicate {
+	return Matchable.KeyCountEQ(column, n).Predicate
+}
+
+// KeyCountEQ returns the KeyCountEQ predicate evaluable by Match.
+func (MatchablePredicates) KeyCountEQ(column string, n int) *Predicate {
+	return keyCount(column, sql.OpEQ, n)
+}
+
+// KeyCountNEQ check if the given column doesn't have n keys.
+func KeyCountNEQ(column string, n int) *sql.Predicate {
+	return Matchable.KeyCountNEQ(column, n).Predicate
+}
+
+// KeyCountNEQ returns the KeyCountNEQ predicate evaluable by Match.
+func (MatchablePredicates) KeyCountNEQ(column string, n int) *Predicate {
+	return keyCount(column, sql.OpNEQ, n)
+}
+
+// KeyCountGT check if the given column has more than n keys.
+func KeyCountGT(column string, n int) *sql.Predicate {
+	return Matchable.KeyCountGT(column, n).Predicate
+}
+
+// KeyCountGT returns the KeyCountGT predicate evaluable by Match.
+func (MatchablePredicates) KeyCountGT(column string, n int) *Predicate {
+	return keyCount(column, sql.OpGT, n)
+}
+
+// KeyCountGTE check if the given column has n or more keys.
+func KeyCountGTE(column string, n int) *sql.Predicate {
+	return Matchable.KeyCountGTE(column, n).Predicate
+}
+
+// KeyCountGTE returns the KeyCountGTE predicate evaluable by Match.
+func (MatchablePredicates) KeyCountGTE(column string, n int) *Predicate {
+	return keyCount(column, sql.OpGTE, n)
+}
+
+// KeyCountLT check if the given column has less than n keys.
+func KeyCountLT(column string, n int) *sql.Predicate {
+	return Matchable.KeyCountLT(column, n).Predicate
+}
+
+// KeyCountLT returns the KeyCountLT predicate evaluable by Match.
+func (MatchablePredicates) KeyCountLT(column string, n int) *Predicate {
+	return keyCount(column, sql.OpLT, n)
+}
+
+// KeyCountLTE check if the given column has n or less keys.
+func KeyCountLTE(column string, n int) *sql.Predicate {
+	return Matchable.KeyCountLTE(column, n).Predicate
+}
+
+// KeyCountLTE returns the KeyCountLTE predicate evaluable by Match.
+func (MatchablePredicates) KeyCountLTE(column string, n int) *Predicate {
+	return keyCount(column, sql.OpLTE, n)
+}
+
+// keyCount compares the number of keys of the column with n, using cardinality(akeys(col)).
+// The array_length of an empty array is NULL, the cardinality is 0 and the cardinality
+// of a NULL column is NULL, so a NULL column is unknown and an empty hstore has 0 keys.
+func keyCount(column string, op sql.Op, n int) *Predicate {
+	return newPredicate(sql.P(func(b *sql.Builder) {
+		writeKeyCount(b, column)
+		b.WriteOp(op).Arg(n)
+	}), matchKeys(func(h Hstore) bool {
+		switch {
+		case len(h) < n:
+			return compareOp(op, -1)
+		case len(h) > n:
+			return compareOp(op, 1)
+		}
+
+		return compareOp(op, 0)
+	}))
 }
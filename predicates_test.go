@@ -22,7 +22,7 @@ func TestHstorePredicates(t *testing.T) {
 				Select("*").
 				From(sql.Table("users")).
 				Where(HasKey("attributes", "'test'")),
-			wantQuery: `SELECT * FROM "users" WHERE exist("attributes", '''test''')`,
+			wantQuery: `SELECT * FROM "users" WHERE "attributes" ? '''test'''`,
 			wantArgs:  nil,
 		},
 		{
@@ -30,7 +30,7 @@ func TestHstorePredicates(t *testing.T) {
 				Select("*").
 				From(sql.Table("users")).
 				Where(HasKey("attributes", "test")),
-			wantQuery: `SELECT * FROM "users" WHERE exist("attributes", 'test')`,
+			wantQuery: `SELECT * FROM "users" WHERE "attributes" ? 'test'`,
 			wantArgs:  nil,
 		},
 		{
@@ -41,6 +41,54 @@ func TestHstorePredicates(t *testing.T) {
 			wantQuery: `SELECT * FROM "users" WHERE "attributes" ?& ARRAY['test','test1']`,
 			wantArgs:  nil,
 		},
+		{
+			input: sql.Dialect(dialect.Postgres).
+				Select("*").
+				From(sql.Table("users")).
+				Where(HasAnyKeys("attributes", "test", "test1")),
+			wantQuery: `SELECT * FROM "users" WHERE "attributes" ?| ARRAY['test','test1']`,
+			wantArgs:  nil,
+		},
+		{
+			input: sql.Dialect(dialect.Postgres).
+				Select("*").
+				From(sql.Table("users")).
+				Where(NotHasKey("attributes", "test")),
+			wantQuery: `SELECT * FROM "users" WHERE NOT ("attributes" ? 'test')`,
+			wantArgs:  nil,
+		},
+		{
+			input: sql.Dialect(dialect.Postgres).
+				Select("*").
+				From(sql.Table("users")).
+				Where(Contains("attributes", FromMap(map[string]string{"a": "b"}))),
+			wantQuery: `SELECT * FROM "users" WHERE "attributes" @> $1::hstore`,
+			wantArgs:  []interface{}{ptr(FromMap(map[string]string{"a": "b"}))},
+		},
+		{
+			input: sql.Dialect(dialect.Postgres).
+				Select("*").
+				From(sql.Table("users")).
+				Where(NotContains("attributes", FromMap(map[string]string{"a": "b"}))),
+			wantQuery: `SELECT * FROM "users" WHERE NOT ("attributes" @> $1::hstore)`,
+			wantArgs:  []interface{}{ptr(FromMap(map[string]string{"a": "b"}))},
+		},
+		{
+			input: sql.Dialect(dialect.Postgres).
+				Select("*").
+				From(sql.Table("users")).
+				Where(ContainedBy("attributes", FromMap(map[string]string{"a": "b"}))),
+			wantQuery: `SELECT * FROM "users" WHERE "attributes" <@ $1::hstore`,
+			wantArgs:  []interface{}{ptr(FromMap(map[string]string{"a": "b"}))},
+		},
+		{
+			input: sql.Dialect(dialect.Postgres).
+				Select("*").
+				From(sql.Table("users")).
+				Where(NotContainedBy("attributes", FromMap(map[string]string{"a": "b"}))),
+			wantQuery: `SELECT * FROM "users" WHERE NOT ("attributes" <@ $1::hstore)`,
+			wantArgs:  []interface{}{ptr(FromMap(map[string]string{"a": "b"}))},
+		},
 		{
 			input: sql.Dialect(dialect.Postgres).
 				Select("*").
@@ -121,6 +169,150 @@ func TestHstorePredicates(t *testing.T) {
 			wantQuery: `SELECT * FROM "users" WHERE "attributes" -> 'key' LIKE $1`,
 			wantArgs:  []interface{}{"%val"},
 		},
+		{
+			input: sql.Dialect(dialect.Postgres).
+				Select("*").
+				From(sql.Table("users")).
+				Where(ValueEqualFold("attributes", "key", "V%l")),
+			wantQuery: `SELECT * FROM "users" WHERE "attributes" -> 'key' ILIKE $1`,
+			wantArgs:  []interface{}{`v\%l`},
+		},
+		{
+			input: sql.Dialect(dialect.Postgres).
+				Select("*").
+				From(sql.Table("users")).
+				Where(ValueContainsFold("attributes", "key", "V_l")),
+			wantQuery: `SELECT * FROM "users" WHERE "attributes" -> 'key' ILIKE $1`,
+			wantArgs:  []interface{}{`%v\_l%`},
+		},
+		{
+			input: sql.Dialect(dialect.Postgres).
+				Select("*").
+				From(sql.Table("users")).
+				Where(ValueLike("attributes", "key", "v%l")),
+			wantQuery: `SELECT * FROM "users" WHERE "attributes" -> 'key' LIKE $1`,
+			wantArgs:  []interface{}{"v%l"},
+		},
+		{
+			input: sql.Dialect(dialect.Postgres).
+				Select("*").
+				From(sql.Table("users")).
+				Where(ValueNotLike("attributes", "key", "v%l")),
+			wantQuery: `SELECT * FROM "users" WHERE "attributes" -> 'key' NOT LIKE $1`,
+			wantArgs:  []interface{}{"v%l"},
+		},
+		{
+			input: sql.Dialect(dialect.Postgres).
+				Select("*").
+				From(sql.Table("users")).
+				Where(ValueRegex("attributes", "key", "^v+$")),
+			wantQuery: `SELECT * FROM "users" WHERE "attributes" -> 'key' ~ $1`,
+			wantArgs:  []interface{}{"^v+$"},
+		},
+		{
+			input: sql.Dialect(dialect.Postgres).
+				Select("*").
+				From(sql.Table("users")).
+				Where(ValueRegexFold("attributes", "key", "^v+$")),
+			wantQuery: `SELECT * FROM "users" WHERE "attributes" -> 'key' ~* $1`,
+			wantArgs:  []interface{}{"^v+$"},
+		},
+		{
+			input: sql.Dialect(dialect.Postgres).
+				Select("*").
+				From(sql.Table("users")).
+				Where(ValueSimilarTo("attributes", "key", "(a|b)%")),
+			wantQuery: `SELECT * FROM "users" WHERE "attributes" -> 'key' SIMILAR TO $1`,
+			wantArgs:  []interface{}{"(a|b)%"},
+		},
+		{
+			input: sql.Dialect(dialect.Postgres).
+				Select("*").
+				From(sql.Table("users")).
+				Where(ValueIn("attributes", "key", "a", "b")),
+			wantQuery: `SELECT * FROM "users" WHERE "attributes" -> 'key' IN ($1, $2)`,
+			wantArgs:  []interface{}{"a", "b"},
+		},
+		{
+			input: sql.Dialect(dialect.Postgres).
+				Select("*").
+				From(sql.Table("users")).
+				Where(ValueIn("attributes", "key")),
+			wantQuery: `SELECT * FROM "users" WHERE FALSE`,
+			wantArgs:  nil,
+		},
+		{
+			input: sql.Dialect(dialect.Postgres).
+				Select("*").
+				From(sql.Table("users")).
+				Where(ValueNotIn("attributes", "key", "a")),
+			wantQuery: `SELECT * FROM "users" WHERE "attributes" -> 'key' NOT IN ($1)`,
+			wantArgs:  []interface{}{"a"},
+		},
+		{
+			input: sql.Dialect(dialect.Postgres).
+				Select("*").
+				From(sql.Table("users")).
+				Where(ValueNotIn("attributes", "key")),
+			wantQuery: `SELECT * FROM "users" WHERE NOT (FALSE)`,
+			wantArgs:  nil,
+		},
+		{
+			input: sql.Dialect(dialect.Postgres).
+				Select("*").
+				From(sql.Table("users")).
+				Where(AnyValueEQ("attributes", "v")),
+			wantQuery: `SELECT * FROM "users" WHERE avals("attributes") @> ARRAY[$1]::text[]`,
+			wantArgs:  []interface{}{"v"},
+		},
+		{
+			input: sql.Dialect(dialect.Postgres).
+				Select("*").
+				From(sql.Table("users")).
+				Where(AnyValueContains("attributes", "v%")),
+			wantQuery: `SELECT * FROM "users" WHERE EXISTS (SELECT 1 FROM svals("attributes") AS v WHERE v LIKE $1)`,
+			wantArgs:  []interface{}{`%v\%%`},
+		},
+		{
+			input: sql.Dialect(dialect.Postgres).
+				Select("*").
+				From(sql.Table("users")).
+				Where(KeyMatches("attributes", "^v")),
+			wantQuery: `SELECT * FROM "users" WHERE EXISTS (SELECT 1 FROM skeys("attributes") AS k WHERE k ~ $1)`,
+			wantArgs:  []interface{}{"^v"},
+		},
+		{
+			input: sql.Dialect(dialect.Postgres).
+				Select("*").
+				From(sql.Table("users")).
+				Where(IsEmpty("attributes")),
+			wantQuery: `SELECT * FROM "users" WHERE "attributes" = ''::hstore`,
+			wantArgs:  nil,
+		},
+		{
+			input: sql.Dialect(dialect.Postgres).
+				Select("*").
+				From(sql.Table("users")).
+				Where(IsNotEmpty("attributes")),
+			wantQuery: `SELECT * FROM "users" WHERE "attributes" <> ''::hstore`,
+			wantArgs:  nil,
+		},
+		{
+			input: sql.Dialect(dialect.Postgres).
+				Select("*").
+				From(sql.Table("users")).
+				Where(KeyCountGT("attributes", 10)),
+			wantQuery: `SELECT * FROM "users" WHERE cardinality(akeys("attributes")) > $1`,
+			wantArgs:  []interface{}{10},
+		},
+		{
+			input: sql.Dialect(dialect.Postgres).
+				Select("*").
+				From(sql.Table("users")).
+				Where(KeyCountLTE("attributes", 1)),
+			wantQuery: `SELECT * FROM "users" WHERE cardinality(akeys("attributes")) <= $1`,
+			wantArgs:  []interface{}{1},
+		},
 	}
 	for i, tt := range tests {
 		tt := tt
@@ -0,0 +1,219 @@
+package enthstore
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strings"
+
+	"entgo.io/ent"
+)
+
+// Schema describes the keys and values accepted by an Hstore.
+//
+//	s := enthstore.Schema{
+//		Keys: map[string]enthstore.KeyRule{
+//			"email": {Required: true, Pattern: regexp.MustCompile(`^\S+@\S+$`)},
+//			"plan":  {Enum: []string{"free", "pro"}},
+//			"age":   {Min: enthstore.Float(0), Max: enthstore.Float(150), Nullable: true},
+//		},
+//		MaxKeys: 10,
+//	}
+type Schema struct {
+	// Keys holds the rules of the known keys.
+	Keys map[string]KeyRule
+	// AllowUnknown allows keys without a rule.
+	AllowUnknown bool
+	// MaxKeys is the maximum number of keys, zero means no limit.
+	MaxKeys int
+}
+
+// KeyRule describes the value accepted by a key.
+type KeyRule struct {
+	// Required rejects an Hstore without the key.
+	Required bool
+	// Nullable allows a NULL value, the other rules are not
+	// checked for NULL values.
+	Nullable bool
+	// Pattern is the regular expression the value must match.
+	Pattern *regexp.Regexp
+	// Enum holds the values allowed, empty allows any value.
+	Enum []string
+	// Min is the minimum numeric value, the value must be numeric when set.
+	Min *float64
+	// Max is the maximum numeric value, the value must be numeric when set.
+	Max *float64
+}
+
+// Float returns a pointer to the value, to be used on KeyRule.Min and KeyRule.Max.
+func Float(v float64) *float64 {
+	return &v
+}
+
+// Violation is a key that doesn't satisfy a Schema.
+type Violation struct {
+	// Key is the key of the violation, empty for the violations of the whole Hstore.
+	Key string
+	// Message describes the violation.
+	Message string
+}
+
+// String returns the key and message of the violation.
+func (v Violation) String() string {
+	if v.Key == "" {
+		return v.Message
+	}
+
+	return fmt.Sprintf("key %q %s", v.Key, v.Message)
+}
+
+// ValidationError is the error returned by Schema.Validate,
+// it holds all the violations found.
+type ValidationError struct {
+	Violations []Violation
+}
+
+// Error implements the interface error.
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		msgs = append(msgs, v.String())
+	}
+
+	return "hstore: invalid value: " + strings.Join(msgs, "; ")
+}
+
+// Validate checks the Hstore against the schema, returning a ValidationError
+// with all the violations found. A nil Hstore is a NULL column and is not validated.
+func (s Schema) Validate(h Hstore) error {
+	if h == nil {
+		return nil
+	}
+
+	var violations []Violation
+	if s.MaxKeys > 0 && len(h) > s.MaxKeys {
+		violations = append(violations, Violation{
+			Message: fmt.Sprintf("has %d keys, the maximum is %d", len(h), s.MaxKeys),
+		})
+	}
+
+	for key, rule := range s.Keys {
+		if !h.Has(key) {
+			if rule.Required {
+				violations = append(violations, Violation{Key: key, Message: "is required"})
+			}
+
+			continue
+		}
+
+		if msg := rule.check(h.Get(key)); msg != "" {
+			violations = append(violations, Violation{Key: key, Message: msg})
+		}
+	}
+
+	if !s.AllowUnknown {
+		for key := range h {
+			if _, found := s.Keys[key]; !found {
+				violations = append(violations, Violation{Key: key, Message: "is not allowed"})
+			}
+		}
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].Key < violations[j].Key
+	})
+
+	return &ValidationError{Violations: violations}
+}
+
+// check returns the violation message of the value, empty when valid.
+func (r KeyRule) check(val *string) string {
+	if val == nil {
+		if r.Nullable {
+			return ""
+		}
+
+		return "must not be null"
+	}
+
+	if r.Pattern != nil && !r.Pattern.MatchString(*val) {
+		return fmt.Sprintf("must match %q", r.Pattern.String())
+	}
+
+	if len(r.Enum) > 0 && !containsString(r.Enum, *val) {
+		return fmt.Sprintf("must be one of %s", strings.Join(r.Enum, ", "))
+	}
+
+	if r.Min == nil && r.Max == nil {
+		return ""
+	}
+
+	n, err := Numeric.parse(*val)
+	if err != nil {
+		return "must be numeric"
+	}
+
+	num, _ := n.(*big.Rat)
+	if r.Min != nil && num.Cmp(new(big.Rat).SetFloat64(*r.Min)) < 0 {
+		return fmt.Sprintf("must be greater or equals to %v", *r.Min)
+	}
+
+	if r.Max != nil && num.Cmp(new(big.Rat).SetFloat64(*r.Max)) > 0 {
+		return fmt.Sprintf("must be smaller or equals to %v", *r.Max)
+	}
+
+	return ""
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Hook returns an ent.Hook that validates the values set on the provided
+// Hstore and Typed fields on creates and updates, the mutation fails
+// with the ValidationError of the field. The changes made by the update
+// modifiers, like SetKey, are not part of the mutation and are not validated.
+//
+// The Other fields of ent don't support validators, so the hook
+// is used in place of field.Other(...).Validate(...):
+//
+//	client.User.Use(schema.Hook(user.FieldAttributes))
+func (s Schema) Hook(fields ...string) ent.Hook {
+	return func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			if !m.Op().Is(ent.OpCreate | ent.OpUpdate | ent.OpUpdateOne) {
+				return next.Mutate(ctx, m)
+			}
+
+			for _, field := range fields {
+				v, found := m.Field(field)
+				if !found {
+					continue
+				}
+
+				h, err := toHstore(v)
+				if err != nil {
+					return nil, err
+				}
+
+				if err := s.Validate(h); err != nil {
+					return nil, fmt.Errorf("%s: validator failed for field %q: %w", m.Type(), field, err)
+				}
+			}
+
+			return next.Mutate(ctx, m)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package enthstore
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"entgo.io/ent"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchema_Validate(t *testing.T) {
+	t.Parallel()
+
+	s := Schema{
+		Keys: map[string]KeyRule{
+			"email": {Required: true, Pattern: regexp.MustCompile(`^\S+@\S+$`)},
+			"plan":  {Enum: []string{"free", "pro"}},
+			"age":   {Min: Float(0), Max: Float(150), Nullable: true},
+		},
+		MaxKeys: 3,
+	}
+
+	tests := []struct {
+		h    Hstore
+		want []Violation
+	}{
+		{h: nil},
+		{h: Hstore{"email": ptrString("a@b.com")}},
+		{h: Hstore{"email": ptrString("a@b.com"), "plan": ptrString("pro"), "age": nil}},
+		{h: Hstore{"email": ptrString("a@b.com"), "age": ptrString("150")}},
+		{
+			h:    Hstore{},
+			want: []Violation{{Key: "email", Message: "is required"}},
+		},
+		{
+			h:    Hstore{"email": nil},
+			want: []Violation{{Key: "email", Message: "must not be null"}},
+		},
+		{
+			h: Hstore{"email": ptrString("invalid"), "plan": ptrString("gold"), "age": ptrString("-1"), "x": nil},
+			want: []Violation{
+				{Message: "has 4 keys, the maximum is 3"},
+				{Key: "age", Message: "must be greater or equals to 0"},
+				{Key: "email", Message: `must match "^\\S+@\\S+$"`},
+				{Key: "plan", Message: "must be one of free, pro"},
+				{Key: "x", Message: "is not allowed"},
+			},
+		},
+		{
+			h: Hstore{"email": ptrString("a@b.com"), "age": ptrString("old")},
+			want: []Violation{
+				{Key: "age", Message: "must be numeric"},
+			},
+		},
+		{
+			h: Hstore{"email": ptrString("a@b.com"), "age": ptrString("150.5")},
+			want: []Violation{
+				{Key: "age", Message: "must be smaller or equals to 150"},
+			},
+		},
+	}
+	for i, tt := range tests {
+		tt := tt
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+			err := s.Validate(tt.h)
+			if tt.want == nil {
+				require.NoError(t, err)
+				return
+			}
+
+			var verr *ValidationError
+			require.ErrorAs(t, err, &verr)
+			require.Equal(t, tt.want, verr.Violations)
+		})
+	}
+}
+
+func TestSchema_ValidateAllowUnknown(t *testing.T) {
+	t.Parallel()
+
+	s := Schema{AllowUnknown: true}
+	require.NoError(t, s.Validate(Hstore{"a": ptrString("b")}))
+
+	s.AllowUnknown = false
+	require.EqualError(t, s.Validate(Hstore{"a": ptrString("b")}), `hstore: invalid value: key "a" is not allowed`)
+}
+
+func TestSchema_Hook(t *testing.T) {
+	t.Parallel()
+
+	s := Schema{Keys: map[string]KeyRule{"a": {Required: true}}}
+
+	var called bool
+	next := ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+		called = true
+		return nil, nil
+	})
+	mutate := func(m *fakeMutation) error {
+		called = false
+		_, err := s.Hook("attributes", "settings")(next).Mutate(context.Background(), m)
+		return err
+	}
+
+	err := mutate(&fakeMutation{op: ent.OpCreate, fields: map[string]ent.Value{"attributes": Hstore{}}})
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	require.EqualError(t, err, `User: validator failed for field "attributes": hstore: invalid value: key "a" is required`)
+	require.False(t, called)
+
+	err = mutate(&fakeMutation{op: ent.OpUpdate, fields: map[string]ent.Value{"settings": NewTyped(struct{}{})}})
+	require.ErrorAs(t, err, &verr)
+	require.False(t, called)
+
+	err = mutate(&fakeMutation{op: ent.OpUpdateOne, fields: map[string]ent.Value{"attributes": Hstore{"a": ptrString("b")}}})
+	require.NoError(t, err)
+	require.True(t, called)
+
+	err = mutate(&fakeMutation{op: ent.OpDelete})
+	require.NoError(t, err)
+	require.True(t, called)
+}
@@ -0,0 +1,74 @@
+package enthstore
+
+import (
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+)
+
+// SelectValue returns a selector modifier that selects the value of the key
+// with the provided alias, the value is NULL when the key doesn't exist.
+// It can be used with the Modify method of the ent queries:
+//
+//	var v []struct {
+//		ID    int            `json:"id"`
+//		Theme sql.NullString `json:"theme"`
+//	}
+//	err := client.User.Query().
+//		Select(user.FieldID).
+//		Modify(enthstore.SelectValue(user.FieldAttributes, "theme", "theme")).
+//		Scan(ctx, &v)
+func SelectValue(column string, key string, alias string) func(*sql.Selector) {
+	return selectExpr("SelectValue", column, alias, func(b *sql.Builder, column string) {
+		b.Ident(column).WriteString(" -> ").WriteString(quoteKey(key))
+	})
+}
+
+// SelectKeys returns a selector modifier that selects the keys of
+// the column as "<column>_keys", the keys can be scanned to a TextArray.
+func SelectKeys(column string) func(*sql.Selector) {
+	return selectExpr("SelectKeys", column, column+"_keys", func(b *sql.Builder, column string) {
+		b.WriteString("akeys(").Ident(column).WriteString(")")
+	})
+}
+
+// SelectValues returns a selector modifier that selects the values of
+// the column as "<column>_values", the values can be scanned to a TextArray.
+func SelectValues(column string) func(*sql.Selector) {
+	return selectExpr("SelectValues", column, column+"_values", func(b *sql.Builder, column string) {
+		b.WriteString("avals(").Ident(column).WriteString(")")
+	})
+}
+
+// SelectSlice returns a selector modifier that selects an Hstore with only the
+// provided keys of the column as "<column>_slice", the missing keys are ignored.
+func SelectSlice(column string, keys ...string) func(*sql.Selector) {
+	return selectExpr("SelectSlice", column, column+"_slice", func(b *sql.Builder, column string) {
+		b.WriteString("slice(").Ident(column).Comma()
+		textArray(b, keys)
+		b.WriteString(")")
+	})
+}
+
+// SelectSize returns a selector modifier that selects the number of pairs
+// of the column as "<column>_size". Like the KeyCount predicates, an empty
+// column has size 0 and the size of a NULL column is NULL.
+func SelectSize(column string) func(*sql.Selector) {
+	return selectExpr("SelectSize", column, column+"_size", writeKeyCount)
+}
+
+// selectExpr appends the expression to the selection, the column is
+// qualified with the table of the selector. The expressions are only
+// supported by Postgres, the other dialects add an error to the builder.
+func selectExpr(name string, column string, alias string, fn func(b *sql.Builder, column string)) func(*sql.Selector) {
+	return func(s *sql.Selector) {
+		s.AppendSelectExprAs(sql.ExprFunc(func(b *sql.Builder) {
+			if isJSONDialect(b.Dialect()) {
+				b.AddError(fmt.Errorf("hstore: %s is not supported by %s", name, b.Dialect()))
+				return
+			}
+
+			fn(b, s.C(column))
+		}), alias)
+	}
+}
@@ -0,0 +1,69 @@
+package enthstore
+
+import (
+	"strconv"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectModifiers(t *testing.T) {
+	t.Parallel()
+
+	query := func(modifiers ...func(*sql.Selector)) sql.Querier {
+		t := sql.Table("users")
+		s := sql.Dialect(dialect.Postgres).Select(t.C("id")).From(t)
+		for _, m := range modifiers {
+			m(s)
+		}
+
+		return s
+	}
+
+	tests := []struct {
+		input     sql.Querier
+		wantQuery string
+		wantArgs  []interface{}
+	}{
+		{
+			input:     query(SelectValue("attributes", "it's", "value")),
+			wantQuery: `SELECT "users"."id", ("users"."attributes" -> 'it''s') AS "value" FROM "users"`,
+		},
+		{
+			input:     query(SelectKeys("attributes"), SelectValues("attributes")),
+			wantQuery: `SELECT "users"."id", (akeys("users"."attributes")) AS "attributes_keys", (avals("users"."attributes")) AS "attributes_values" FROM "users"`,
+		},
+		{
+			input:     query(SelectSlice("attributes", "a", "b")),
+			wantQuery: `SELECT "users"."id", (slice("users"."attributes", ARRAY[$1, $2]::text[])) AS "attributes_slice" FROM "users"`,
+			wantArgs:  []interface{}{"a", "b"},
+		},
+		{
+			input:     query(SelectSize("attributes")),
+			wantQuery: `SELECT "users"."id", (cardinality(akeys("users"."attributes"))) AS "attributes_size" FROM "users"`,
+		},
+	}
+	for i, tt := range tests {
+		tt := tt
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+			query, args := tt.input.Query()
+			require.Equal(t, tt.wantQuery, query)
+			require.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestSelectModifiersJSON(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{dialect.SQLite, dialect.MySQL} {
+		users := sql.Table("users")
+		s := sql.Dialect(name).Select(users.C("id")).From(users)
+		SelectKeys("attributes")(s)
+		s.Query()
+		require.EqualError(t, s.Err(), "hstore: SelectKeys is not supported by "+name)
+	}
+}
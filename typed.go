@@ -0,0 +1,82 @@
+package enthstore
+
+import (
+	"database/sql/driver"
+
+	"entgo.io/ent/dialect/sql"
+)
+
+// Typed is an hstore column exposed as the struct T, the
+// struct is converted from and to Hstore using Marshal and Unmarshal,
+// so T follows the same rules and supports the same `hstore` tags.
+//
+// It can be used as an ent field like Hstore:
+//
+//	field.Other("attributes", enthstore.Typed[Attributes]{}).
+//		SchemaType(enthstore.Typed[Attributes]{}.SchemaType())
+type Typed[T any] struct {
+	Data T
+}
+
+// NewTyped creates a new Typed from the struct.
+func NewTyped[T any](data T) Typed[T] {
+	return Typed[T]{Data: data}
+}
+
+// Hstore returns the Hstore representation of the struct.
+func (t Typed[T]) Hstore() (Hstore, error) {
+	return Marshal(&t.Data)
+}
+
+// Scan implements the interface Scanner.
+//
+// A NULL value resets the struct to its zero value.
+func (t *Typed[T]) Scan(value interface{}) error {
+	return t.scan(value, (*Hstore).ScanStrict)
+}
+
+// scanJSON decodes the JSON object stored by SQLite and MySQL.
+func (t *Typed[T]) scanJSON(value interface{}) error {
+	return t.scan(value, (*Hstore).scanJSON)
+}
+
+func (t *Typed[T]) scan(value interface{}, scan func(*Hstore, interface{}) error) error {
+	var data T
+	if value == nil {
+		t.Data = data
+		return nil
+	}
+
+	hs := Hstore{}
+	if err := scan(&hs, value); err != nil {
+		return err
+	}
+
+	if err := Unmarshal(hs, &data); err != nil {
+		return err
+	}
+
+	t.Data = data
+	return nil
+}
+
+// Value implements the interface driver.Valuer.
+func (t Typed[T]) Value() (driver.Value, error) {
+	hs, err := t.Hstore()
+	if err != nil {
+		return nil, err
+	}
+
+	return hs.Value()
+}
+
+// FormatParam defines how format the placeholder, the
+// dialects that store the Hstore as JSON validate the JSON.
+func (Typed[T]) FormatParam(param string, info *sql.StmtInfo) string {
+	return formatParam(param, info)
+}
+
+// SchemaType defines the schema-type of the Typed object.
+func (Typed[T]) SchemaType() map[string]string {
+	return Hstore{}.SchemaType()
+}
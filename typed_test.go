@@ -0,0 +1,83 @@
+package enthstore
+
+import (
+	"strconv"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"github.com/stretchr/testify/require"
+)
+
+type typedStruct struct {
+	Name  string  `hstore:"name"`
+	Age   int     `hstore:"age,omitempty"`
+	Email *string `hstore:"email"`
+}
+
+func TestTyped_Value(t *testing.T) {
+	t.Parallel()
+
+	email := "email"
+	tests := []struct {
+		input typedStruct
+		want  string
+	}{
+		{input: typedStruct{}, want: `"name"=>"","email"=>NULL`},
+		{input: typedStruct{Name: "a", Age: 10, Email: &email}, want: `"age"=>"10","name"=>"a","email"=>"email"`},
+	}
+	for i, tt := range tests {
+		tt := tt
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+			got, err := NewTyped(tt.input).Value()
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTyped_Scan(t *testing.T) {
+	t.Parallel()
+
+	email := "email"
+	tests := []struct {
+		input   interface{}
+		want    typedStruct
+		wantErr bool
+	}{
+		{input: nil, want: typedStruct{}},
+		{input: `"name"=>"a","age"=>"10","email"=>"email"`, want: typedStruct{Name: "a", Age: 10, Email: &email}},
+		{input: []byte(`"name"=>"a","email"=>NULL,"other"=>"b"`), want: typedStruct{Name: "a"}},
+		{input: `"age"=>"a"`, wantErr: true},
+		{input: `"name"=>`, wantErr: true},
+	}
+	for i, tt := range tests {
+		tt := tt
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+			got := NewTyped(typedStruct{Name: "previous", Age: 1})
+			err := got.Scan(tt.input)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got.Data)
+		})
+	}
+}
+
+func TestTyped_FormatParam(t *testing.T) {
+	t.Parallel()
+
+	typed := NewTyped(typedStruct{Name: "a"})
+	query, args := sql.Dialect(dialect.Postgres).
+		Update("users").
+		Set("settings", &typed).
+		Query()
+	require.Equal(t, `UPDATE "users" SET "settings" = $1::hstore`, query)
+	require.Equal(t, []interface{}{&typed}, args)
+	require.Equal(t, Hstore{}.SchemaType(), Typed[typedStruct]{}.SchemaType())
+}
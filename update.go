@@ -0,0 +1,120 @@
+package enthstore
+
+import (
+	"entgo.io/ent/dialect/sql"
+)
+
+// SetKey returns an update modifier that sets the value of the given key,
+// keeping the other keys of the column untouched. It can be used
+// with the Modify method of the ent update builders:
+//
+//	client.User.UpdateOneID(id).
+//		Modify(enthstore.SetKey(user.FieldAttributes, "a", "b")).
+//		Exec(ctx)
+//
+// The modifiers assign the whole column with UpdateBuilder.Set, so a later
+// modifier of the same column replaces the previous one, use ApplyPatch to
+// set and delete keys of a column in the same update.
+func SetKey(column string, key string, val string) func(*sql.UpdateBuilder) {
+	return func(u *sql.UpdateBuilder) {
+		u.Set(column, sql.ExprFunc(func(b *sql.Builder) {
+			coalesce(b, column)
+			b.WriteString(" || hstore(").Arg(key).WriteString("::text").Comma().Arg(val).WriteString("::text)")
+		}))
+	}
+}
+
+// Merge returns an update modifier that merges the provided Hstore
+// into the column, overwriting the values of the existing keys.
+func Merge(column string, h Hstore) func(*sql.UpdateBuilder) {
+	return func(u *sql.UpdateBuilder) {
+		u.Set(column, sql.ExprFunc(func(b *sql.Builder) {
+			coalesce(b, column)
+			b.WriteString(" || ").Arg(&h)
+		}))
+	}
+}
+
+// DeleteKeys returns an update modifier that deletes the provided keys from the column.
+func DeleteKeys(column string, keys ...string) func(*sql.UpdateBuilder) {
+	return func(u *sql.UpdateBuilder) {
+		if len(keys) == 0 {
+			return
+		}
+
+		u.Set(column, sql.ExprFunc(func(b *sql.Builder) {
+			b.Ident(column).WriteString(" - ")
+			textArray(b, keys)
+		}))
+	}
+}
+
+// DeletePairs returns an update modifier that deletes the pairs from the column
+// that match the provided Hstore, a key is only deleted when the value is equal.
+func DeletePairs(column string, h Hstore) func(*sql.UpdateBuilder) {
+	return func(u *sql.UpdateBuilder) {
+		u.Set(column, sql.ExprFunc(func(b *sql.Builder) {
+			b.WriteString("delete(").Ident(column).Comma().Arg(&h).WriteString(")")
+		}))
+	}
+}
+
+// ApplyPatch returns an update modifier that applies the patch to the column,
+// writing only the pairs added or changed and deleting the removed keys:
+//
+//	patch := enthstore.Diff(u.Attributes, attributes)
+//	client.User.UpdateOneID(id).
+//		Modify(enthstore.ApplyPatch(user.FieldAttributes, patch)).
+//		Exec(ctx)
+func ApplyPatch(column string, p Patch) func(*sql.UpdateBuilder) {
+	return func(u *sql.UpdateBuilder) {
+		if p.IsEmpty() {
+			return
+		}
+
+		removed, upserts := p.removedKeys(), p.upserts()
+		u.Set(column, sql.ExprFunc(func(b *sql.Builder) {
+			if len(removed) > 0 && len(upserts) > 0 {
+				b.WriteString("(")
+			}
+
+			if len(upserts) > 0 {
+				coalesce(b, column)
+			} else {
+				b.Ident(column)
+			}
+
+			if len(removed) > 0 {
+				b.WriteString(" - ")
+				textArray(b, removed)
+			}
+
+			if len(removed) > 0 && len(upserts) > 0 {
+				b.WriteString(")")
+			}
+
+			if len(upserts) > 0 {
+				b.WriteString(" || ").Arg(&upserts)
+			}
+		}))
+	}
+}
+
+// coalesce writes the column replacing a NULL column with
+// an empty hstore, since concatenating with NULL yields NULL.
+func coalesce(b *sql.Builder, column string) {
+	b.WriteString("COALESCE(").Ident(column).WriteString(", ''::hstore)")
+}
+
+// textArray writes the values as a text array of arguments.
+func textArray(b *sql.Builder, values []string) {
+	b.WriteString("ARRAY[")
+	for i, v := range values {
+		if i > 0 {
+			b.Comma()
+		}
+
+		b.Arg(v)
+	}
+	b.WriteString("]::text[]")
+}
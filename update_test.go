@@ -0,0 +1,95 @@
+package enthstore
+
+import (
+	"strconv"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateModifiers(t *testing.T) {
+	t.Parallel()
+
+	update := func(modifiers ...func(*sql.UpdateBuilder)) *sql.UpdateBuilder {
+		u := sql.Dialect(dialect.Postgres).Update("users").Where(sql.EQ("id", 1))
+		for _, m := range modifiers {
+			m(u)
+		}
+
+		return u
+	}
+
+	tests := []struct {
+		input     sql.Querier
+		wantQuery string
+		wantArgs  []interface{}
+	}{
+		{
+			input:     update(SetKey("attributes", "key", "val")),
+			wantQuery: `UPDATE "users" SET "attributes" = COALESCE("attributes", ''::hstore) || hstore($1::text, $2::text) WHERE "id" = $3`,
+			wantArgs:  []interface{}{"key", "val", 1},
+		},
+		{
+			input:     update(Merge("attributes", FromMap(map[string]string{"a": "b"}))),
+			wantQuery: `UPDATE "users" SET "attributes" = COALESCE("attributes", ''::hstore) || $1::hstore WHERE "id" = $2`,
+			wantArgs:  []interface{}{ptr(FromMap(map[string]string{"a": "b"})), 1},
+		},
+		{
+			input:     update(DeleteKeys("attributes", "a", "b")),
+			wantQuery: `UPDATE "users" SET "attributes" = "attributes" - ARRAY[$1, $2]::text[] WHERE "id" = $3`,
+			wantArgs:  []interface{}{"a", "b", 1},
+		},
+		{
+			input:     update(DeleteKeys("attributes"), SetKey("settings", "a", "b")),
+			wantQuery: `UPDATE "users" SET "settings" = COALESCE("settings", ''::hstore) || hstore($1::text, $2::text) WHERE "id" = $3`,
+			wantArgs:  []interface{}{"a", "b", 1},
+		},
+		{
+			// the modifiers of the same column replace each other.
+			input:     update(SetKey("attributes", "a", "b"), DeleteKeys("attributes", "c")),
+			wantQuery: `UPDATE "users" SET "attributes" = "attributes" - ARRAY[$1]::text[] WHERE "id" = $2`,
+			wantArgs:  []interface{}{"c", 1},
+		},
+		{
+			input:     update(DeletePairs("attributes", FromMap(map[string]string{"a": "b"}))),
+			wantQuery: `UPDATE "users" SET "attributes" = delete("attributes", $1::hstore) WHERE "id" = $2`,
+			wantArgs:  []interface{}{ptr(FromMap(map[string]string{"a": "b"})), 1},
+		},
+		{
+			input:     update(ApplyPatch("attributes", Patch{}), SetKey("settings", "a", "b")),
+			wantQuery: `UPDATE "users" SET "settings" = COALESCE("settings", ''::hstore) || hstore($1::text, $2::text) WHERE "id" = $3`,
+			wantArgs:  []interface{}{"a", "b", 1},
+		},
+		{
+			input:     update(ApplyPatch("attributes", Diff(Hstore{"a": nil, "b": nil}, Hstore{}))),
+			wantQuery: `UPDATE "users" SET "attributes" = "attributes" - ARRAY[$1, $2]::text[] WHERE "id" = $3`,
+			wantArgs:  []interface{}{"a", "b", 1},
+		},
+		{
+			input:     update(ApplyPatch("attributes", Diff(Hstore{"a": nil}, Hstore{"a": ptrString("b")}))),
+			wantQuery: `UPDATE "users" SET "attributes" = COALESCE("attributes", ''::hstore) || $1::hstore WHERE "id" = $2`,
+			wantArgs:  []interface{}{ptr(Hstore{"a": ptrString("b")}), 1},
+		},
+		{
+			input: update(ApplyPatch("attributes", Diff(Hstore{"a": nil, "c": nil}, Hstore{"a": ptrString("b"), "d": nil}))),
+			wantQuery: `UPDATE "users" SET "attributes" = (COALESCE("attributes", ''::hstore) - ARRAY[$1]::text[]) || $2::hstore ` +
+				`WHERE "id" = $3`,
+			wantArgs: []interface{}{"c", ptr(Hstore{"a": ptrString("b"), "d": nil}), 1},
+		},
+	}
+	for i, tt := range tests {
+		tt := tt
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+			query, args := tt.input.Query()
+			require.Equal(t, tt.wantQuery, query)
+			require.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func ptr(h Hstore) *Hstore {
+	return &h
+}